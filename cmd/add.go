@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/program"
+	"github.com/melkeydev/go-blueprint/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	var features flags.AdvancedFeatures
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().Var(&features, "feature", fmt.Sprintf("Advanced feature to add. Allowed values: %s", strings.Join(flags.AllowedAdvancedFeatures, ", ")))
+	addCmd.Flags().String("path", ".", "Path of the project generated by go-blueprint")
+	addTemplateDirFlag(addCmd)
+
+	utils.RegisterStaticCompletions(addCmd, "feature", flags.AllowedAdvancedFeatures)
+}
+
+// addCmd defines the "add" command for the CLI
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add advanced features to an existing Blueprint project",
+	Long: `Add advanced features to a project previously generated with Go Blueprint.
+The framework and database driver of the project are detected, and only the files the features need are written.
+Files modified since the project was generated are never overwritten.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		features := strings.Split(cmd.Flag("feature").Value.String(), ",")
+		if features[0] == "" {
+			cobra.CheckErr(fmt.Errorf("at least one --feature is required. Allowed values: %s", strings.Join(flags.AllowedAdvancedFeatures, ", ")))
+		}
+
+		projectPath, err := filepath.Abs(cmd.Flag("path").Value.String())
+		cobra.CheckErr(err)
+		cobra.CheckErr(loadTemplateOverlays(cmd))
+
+		project, err := program.DetectProject(projectPath)
+		cobra.CheckErr(err)
+		project.BlueprintVersion = getGoBlueprintVersion()
+
+		fmt.Println(tipMsgStyle.Render(fmt.Sprintf("Detected %s project using %s with driver %s", project.ProjectName, project.ProjectType, project.DBDriver)))
+
+		plan, err := project.PlanFeatures(projectPath, features)
+		cobra.CheckErr(err)
+
+		if len(plan.Modified) > 0 {
+			fmt.Println(endingMsgStyle.Render("\nThe following files were modified since the project was generated and would be overwritten:"))
+			for _, file := range plan.Modified {
+				fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• %s", file)))
+			}
+			fmt.Println()
+			cobra.CheckErr(fmt.Errorf("refusing to add %s: %d generated files were modified: %s", strings.Join(features, ", "), len(plan.Modified), strings.Join(plan.Modified, ", ")))
+		}
+
+		if len(plan.Files) == 0 {
+			fmt.Println(endingMsgStyle.Render("\nNothing to add, the project already has every requested feature."))
+			return
+		}
+
+		if err := plan.Apply(); err != nil {
+			log.Printf("Problem adding features to project.")
+			cobra.CheckErr(err)
+		}
+
+		fmt.Println(endingMsgStyle.Render(fmt.Sprintf("\nAdded %s:", strings.Join(features, ", "))))
+		for _, file := range plan.Files {
+			status := "created"
+			if file.Exists {
+				status = "updated"
+			}
+			fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• %s (%s)", file.Path, status)))
+		}
+
+		for _, feature := range features {
+			if feature == flags.Htmx || feature == flags.Tailwind {
+				fmt.Println(endingMsgStyle.Render("\n• Generate templ function files by running `templ generate`"))
+				break
+			}
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+			fmt.Println(tipMsgStyle.Render("\nReview and commit the changes with git"))
+		}
+	},
+}
@@ -0,0 +1,169 @@
+// Package archive packs a project generated in
+// memory into a downloadable archive
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+)
+
+// Format is a supported archive format
+type Format string
+
+const (
+	TarGz Format = "tar.gz"
+	Zip   Format = "zip"
+)
+
+// FormatFromName returns the Format matching the
+// extension of the given archive file name
+func FormatFromName(name string) (Format, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return TarGz, nil
+	case strings.HasSuffix(lower, ".zip"):
+		return Zip, nil
+	}
+
+	return "", fmt.Errorf("unsupported archive '%s'. Allowed extensions: .tar.gz, .tgz, .zip", name)
+}
+
+// Write packs every file of memory found under root into w. Entries
+// are named relative to root, so an archive of "/tmp" holding the
+// project "/tmp/app" extracts into "app/"
+func Write(w io.Writer, format Format, memory *filesystem.Memory, root string) error {
+	root = path.Clean(filepath.ToSlash(root))
+
+	var names []string
+	for _, name := range memory.Paths() {
+		rel := strings.TrimPrefix(name, root+"/")
+		if rel == name || rel == "" {
+			continue
+		}
+		names = append(names, rel)
+	}
+
+	switch format {
+	case TarGz:
+		return writeTarGz(w, memory, root, names)
+	case Zip:
+		return writeZip(w, memory, root, names)
+	}
+
+	return fmt.Errorf("unsupported archive format '%s'", format)
+}
+
+func writeTarGz(w io.Writer, memory *filesystem.Memory, root string, names []string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	written := make(map[string]bool)
+	for _, name := range names {
+		// Parent directories come first so that every
+		// extractor recreates them with sane permissions
+		for _, dir := range parents(name) {
+			if written[dir] {
+				continue
+			}
+			written[dir] = true
+			header := &tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     dir + "/",
+				Mode:     0o755,
+				ModTime:  now,
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+		}
+
+		content, err := memory.ReadFile(path.Join(root, name))
+		if err != nil {
+			return err
+		}
+		info, err := memory.Stat(path.Join(root, name))
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     int64(info.Mode().Perm()),
+			Size:     int64(len(content)),
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeZip(w io.Writer, memory *filesystem.Memory, root string, names []string) error {
+	zw := zip.NewWriter(w)
+	now := time.Now()
+
+	written := make(map[string]bool)
+	for _, name := range names {
+		for _, dir := range parents(name) {
+			if written[dir] {
+				continue
+			}
+			written[dir] = true
+			header := &zip.FileHeader{Name: dir + "/", Modified: now}
+			header.SetMode(fs.ModeDir | 0o755)
+			if _, err := zw.CreateHeader(header); err != nil {
+				return err
+			}
+		}
+
+		content, err := memory.ReadFile(path.Join(root, name))
+		if err != nil {
+			return err
+		}
+		info, err := memory.Stat(path.Join(root, name))
+		if err != nil {
+			return err
+		}
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now}
+		header.SetMode(info.Mode().Perm())
+		file, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(content); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// parents returns every parent directory of the
+// slash separated name, outermost first
+func parents(name string) []string {
+	var dirs []string
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
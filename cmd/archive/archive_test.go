@@ -0,0 +1,106 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+)
+
+func newTestMemory(t *testing.T) *filesystem.Memory {
+	t.Helper()
+
+	memory := filesystem.NewMemory()
+	if err := memory.MkdirAll("/tmp/app/cmd/api", 0o751); err != nil {
+		t.Fatal(err)
+	}
+	if err := memory.WriteFile("/tmp/app/go.mod", []byte("module app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := memory.WriteFile("/tmp/app/cmd/api/main.go", []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return memory
+}
+
+func TestFormatFromName(t *testing.T) {
+	testCases := map[string]Format{
+		"app.tar.gz": TarGz,
+		"APP.TGZ":    TarGz,
+		"app.zip":    Zip,
+	}
+	for name, expected := range testCases {
+		format, err := FormatFromName(name)
+		if err != nil || format != expected {
+			t.Errorf("testing:%s expected:%s got:%s (%v)", name, expected, format, err)
+		}
+	}
+
+	if _, err := FormatFromName("app.rar"); err == nil {
+		t.Errorf("expected an error for an unsupported extension")
+	}
+}
+
+func TestWriteTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, TarGz, newTestMemory(t), "/tmp"); err != nil {
+		t.Fatalf("could not write archive: %v", err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+
+	var names []string
+	contents := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+		content, _ := io.ReadAll(tr)
+		contents[header.Name] = string(content)
+	}
+
+	expected := []string{"app/", "app/cmd/", "app/cmd/api/", "app/cmd/api/main.go", "app/go.mod"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected entries %v, got %v", expected, names)
+	}
+	if contents["app/go.mod"] != "module app\n" {
+		t.Errorf("unexpected go.mod content %q", contents["app/go.mod"])
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Zip, newTestMemory(t), "/tmp"); err != nil {
+		t.Fatalf("could not write archive: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	expected := []string{"app/", "app/cmd/", "app/cmd/api/", "app/cmd/api/main.go", "app/go.mod"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected entries %v, got %v", expected, names)
+	}
+}
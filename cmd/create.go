@@ -9,7 +9,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/melkeydev/go-blueprint/cmd/archive"
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
 	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/openapi"
 	"github.com/melkeydev/go-blueprint/cmd/program"
 	"github.com/melkeydev/go-blueprint/cmd/steps"
 	"github.com/melkeydev/go-blueprint/cmd/ui/multiInput"
@@ -42,19 +45,29 @@ var (
 func init() {
 	var flagFramework flags.Framework
 	var flagDBDriver flags.Database
+	var flagORM flags.ORM
 	var advancedFeatures flags.AdvancedFeatures
 	var flagGit flags.Git
 	rootCmd.AddCommand(createCmd)
 
 	createCmd.Flags().StringP("name", "n", "", "Name of project to create")
 	createCmd.Flags().VarP(&flagFramework, "framework", "f", fmt.Sprintf("Framework to use. Allowed values: %s", strings.Join(flags.AllowedProjectTypes, ", ")))
-	createCmd.Flags().VarP(&flagDBDriver, "driver", "d", fmt.Sprintf("Database drivers to use. Allowed values: %s", strings.Join(flags.AllowedDBDrivers, ", ")))
+	createCmd.Flags().VarP(&flagDBDriver, "driver", "d", fmt.Sprintf("Database drivers to use, separated by commas for several. Allowed values: %s", strings.Join(flags.AllowedDBDrivers, ", ")))
+	createCmd.Flags().Var(&flagORM, "orm", fmt.Sprintf("ORM the SQL databases are queried with, database/sql with none. Allowed values: %s", strings.Join(flags.AllowedORMs, ", ")))
 	createCmd.Flags().BoolP("advanced", "a", false, "Get prompts for advanced features")
 	createCmd.Flags().Var(&advancedFeatures, "feature", fmt.Sprintf("Advanced feature to use. Allowed values: %s", strings.Join(flags.AllowedAdvancedFeatures, ", ")))
 	createCmd.Flags().VarP(&flagGit, "git", "g", fmt.Sprintf("Git to use. Allowed values: %s", strings.Join(flags.AllowedGitsOptions, ", ")))
+	createCmd.Flags().Bool("dry-run", false, "Render the project in memory and print the files it would create, without writing them or running any external command")
+	createCmd.Flags().Bool("diff", false, "With --dry-run, print every file as a diff against the current content of the target directory")
+	createCmd.Flags().StringP("config", "c", "", "YAML or JSON file declaring the name, framework, driver, features and git option of the project. Flags given on the command line override its values")
+	createCmd.Flags().String("openapi", "", "OpenAPI 3 document, in YAML or JSON, to generate the routes, handlers and types of the project from")
+	createCmd.Flags().String("output-archive", "", "Write the project into a .tar.gz, .tgz or .zip archive instead of the current directory. Dependencies are left for 'go mod tidy' to resolve")
+
+	addTemplateDirFlag(createCmd)
 
 	utils.RegisterStaticCompletions(createCmd, "framework", flags.AllowedProjectTypes)
 	utils.RegisterStaticCompletions(createCmd, "driver", flags.AllowedDBDrivers)
+	utils.RegisterStaticCompletions(createCmd, "orm", flags.AllowedORMs)
 	utils.RegisterStaticCompletions(createCmd, "feature", flags.AllowedAdvancedFeatures)
 	utils.RegisterStaticCompletions(createCmd, "git", flags.AllowedGitsOptions)
 }
@@ -63,6 +76,7 @@ type Options struct {
 	ProjectName *textinput.Output
 	ProjectType *multiInput.Selection
 	DBDriver    *multiInput.Selection
+	ORM         *multiInput.Selection
 	Advanced    *multiSelect.Selection
 	Workflow    *multiInput.Selection
 	Git         *multiInput.Selection
@@ -79,15 +93,46 @@ var createCmd = &cobra.Command{
 		var err error
 
 		isInteractive := false
+
+		cobra.CheckErr(loadTemplateOverlays(cmd))
+
+		if flagConfig := cmd.Flag("config").Value.String(); flagConfig != "" {
+			config, err := flags.LoadConfig(flagConfig)
+			cobra.CheckErr(err)
+			cobra.CheckErr(config.Apply(cmd.Flags()))
+		}
+
 		flagName := cmd.Flag("name").Value.String()
 
+		var spec *openapi.Spec
+		if flagOpenAPI := cmd.Flag("openapi").Value.String(); flagOpenAPI != "" {
+			spec, err = openapi.Load(flagOpenAPI)
+			cobra.CheckErr(err)
+		}
+
+		flagDryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal("failed to retrieve dry-run flag")
+		}
+
+		flagArchive := cmd.Flag("output-archive").Value.String()
+		var archiveFormat archive.Format
+		if flagArchive != "" {
+			archiveFormat, err = archive.FormatFromName(flagArchive)
+			cobra.CheckErr(err)
+		}
+
+		// Dry runs and archives are generated in memory, leaving
+		// the current directory untouched
+		inMemory := flagDryRun || flagArchive != ""
+
 		if flagName != "" && !utils.ValidateModuleName(flagName) {
 			err = fmt.Errorf("'%s' is not a valid module name. Please choose a different name", flagName)
 			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
 		}
 
 		rootDirName := utils.GetRootDir(flagName)
-		if rootDirName != "" && doesDirectoryExistAndIsNotEmpty(rootDirName) {
+		if rootDirName != "" && !inMemory && doesDirectoryExistAndIsNotEmpty(rootDirName) {
 			err = fmt.Errorf("directory '%s' already exists and is not empty. Please choose a different name", rootDirName)
 			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
 		}
@@ -96,12 +141,14 @@ var createCmd = &cobra.Command{
 		// If this flag is filled, it is always valid
 		flagFramework := flags.Framework(cmd.Flag("framework").Value.String())
 		flagDBDriver := flags.Database(cmd.Flag("driver").Value.String())
+		flagORM := flags.ORM(cmd.Flag("orm").Value.String())
 		flagGit := flags.Git(cmd.Flag("git").Value.String())
 
 		options := Options{
 			ProjectName: &textinput.Output{},
 			ProjectType: &multiInput.Selection{},
 			DBDriver:    &multiInput.Selection{},
+			ORM:         &multiInput.Selection{},
 			Advanced: &multiSelect.Selection{
 				Choices: make(map[string]bool),
 			},
@@ -109,16 +156,19 @@ var createCmd = &cobra.Command{
 		}
 
 		project := &program.Project{
-			ProjectName:     flagName,
-			ProjectType:     flagFramework,
-			DBDriver:        flagDBDriver,
-			FrameworkMap:    make(map[flags.Framework]program.Framework),
-			DBDriverMap:     make(map[flags.Database]program.Driver),
-			AdvancedOptions: make(map[string]bool),
-			GitOptions:      flagGit,
+			ProjectName:      flagName,
+			ProjectType:      flagFramework,
+			DBDriver:         flagDBDriver,
+			ORM:              flagORM,
+			FrameworkMap:     make(map[flags.Framework]program.Framework),
+			DBDriverMap:      make(map[flags.Database]program.Driver),
+			AdvancedOptions:  make(map[string]bool),
+			GitOptions:       flagGit,
+			BlueprintVersion: getGoBlueprintVersion(),
+			OpenAPI:          spec,
 		}
 
-		steps := steps.InitSteps(flagFramework, flagDBDriver)
+		steps := steps.InitSteps(flagFramework, flagDBDriver, flagORM)
 		fmt.Printf("%s\n", logoStyle.Render(logo))
 
 		// Advanced option steps:
@@ -145,7 +195,7 @@ var createCmd = &cobra.Command{
 			}
 
 			rootDirName = utils.GetRootDir(options.ProjectName.Output)
-			if doesDirectoryExistAndIsNotEmpty(rootDirName) {
+			if !inMemory && doesDirectoryExistAndIsNotEmpty(rootDirName) {
 				err = fmt.Errorf("directory '%s' already exists and is not empty. Please choose a different name", rootDirName)
 				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
 			}
@@ -196,6 +246,23 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		// The ORM is only asked for along with the other options,
+		// commands without --orm keep generating database/sql
+		if project.ORM == "" && isInteractive && project.SupportsORM() {
+			step := steps.Steps["orm"]
+			tprogram = tea.NewProgram(multiInput.InitialModelMulti(step.Options, options.ORM, step.Headers, project))
+			if _, err := tprogram.Run(); err != nil {
+				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+			}
+			project.ExitCLI(tprogram)
+
+			project.ORM = flags.ORM(strings.ToLower(options.ORM.Choice))
+			err := cmd.Flag("orm").Value.Set(project.ORM.String())
+			if err != nil {
+				log.Fatal("failed to set the orm flag value", err)
+			}
+		}
+
 		if flagAdvanced {
 
 			featureFlags := cmd.Flag("feature").Value.String()
@@ -250,6 +317,40 @@ var createCmd = &cobra.Command{
 		}
 		project.AbsolutePath = currentWorkingDir
 
+		if inMemory {
+			memory := filesystem.NewMemory()
+			project.FS = memory
+			err = project.CreateMainFile()
+			if err != nil {
+				log.Printf("Problem rendering files for project.")
+				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+			}
+
+			if flagDryRun {
+				flagDiff, err := cmd.Flags().GetBool("diff")
+				if err != nil {
+					log.Fatal("failed to retrieve diff flag")
+				}
+				printDryRun(project, memory, flagDiff)
+			}
+			printUnusedOverlays()
+
+			if flagArchive != "" {
+				err = writeArchive(flagArchive, archiveFormat, project, memory)
+				if err != nil {
+					log.Printf("Problem writing archive for project.")
+					cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+				}
+			}
+
+			if isInteractive {
+				nonInteractiveCommand := utils.NonInteractiveCommand(cmd.Use, cmd.Flags())
+				fmt.Println(tipMsgStyle.Render("\nTip: Repeat the equivalent Blueprint with the following non-interactive command:"))
+				fmt.Println(tipMsgStyle.Italic(false).Render(fmt.Sprintf("• %s\n", nonInteractiveCommand)))
+			}
+			return
+		}
+
 		spinner := tea.NewProgram(spinner.InitialModelNew())
 
 		// add synchronization to wait for spinner to finish
@@ -282,6 +383,8 @@ var createCmd = &cobra.Command{
 			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
 		}
 
+		printUnusedOverlays()
+
 		fmt.Println(endingMsgStyle.Render("\nNext steps:"))
 		fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• cd into the newly created project with: `cd %s`\n", utils.GetRootDir(project.ProjectName))))
 
@@ -318,6 +421,34 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// writeArchive packs the project generated in memory into the
+// archive at name, then lists the steps left to the user
+func writeArchive(name string, format archive.Format, project *program.Project, memory *filesystem.Memory) error {
+	archiveFile, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	err = archive.Write(archiveFile, format, memory, project.AbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(endingMsgStyle.Render(fmt.Sprintf("\nProject archived into %s", name)))
+	fmt.Println(endingMsgStyle.Render("\nNext steps:"))
+	fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• extract the archive and cd into the project with: `cd %s`\n", utils.GetRootDir(project.ProjectName))))
+	fmt.Println(endingMsgStyle.Render("• resolve the dependencies and create go.sum with: `go mod tidy`\n"))
+	if len(project.SkippedCommands) > 0 {
+		fmt.Println(tipMsgStyle.Render("The following commands were skipped while archiving:"))
+		for _, command := range project.SkippedCommands {
+			fmt.Println(tipMsgStyle.Italic(false).Render(fmt.Sprintf("• %s", command)))
+		}
+	}
+
+	return archiveFile.Close()
+}
+
 // doesDirectoryExistAndIsNotEmpty checks if the directory exists and is not empty
 func doesDirectoryExistAndIsNotEmpty(name string) bool {
 	if _, err := os.Stat(name); err == nil {
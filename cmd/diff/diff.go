@@ -0,0 +1,190 @@
+// Package diff provides line based comparison of
+// the files rendered by Blueprint
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind tells whether a Line is shared by both inputs,
+// only present in the old one or only in the new one
+type Kind int
+
+const (
+	Equal Kind = iota
+	Delete
+	Insert
+)
+
+// A Line is a single line of an edit script
+type Line struct {
+	Kind Kind
+	Text string
+}
+
+// SplitLines splits content into lines, keeping the trailing
+// newline out of the last element
+func SplitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	text := strings.TrimSuffix(string(content), "\n")
+	return strings.Split(text, "\n")
+}
+
+// Lines returns the edit script that turns a into b, computed
+// from the longest common subsequence of both inputs
+func Lines(a, b []string) []Line {
+	// Common prefix and suffix are cheap to strip and keep the
+	// table below small for the usual case of a few changed lines
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	script := make([]Line, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		script = append(script, Line{Kind: Equal, Text: text})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	// lcs[i][j] holds the length of the longest common
+	// subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			script = append(script, Line{Kind: Equal, Text: midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			script = append(script, Line{Kind: Delete, Text: midA[i]})
+			i++
+		default:
+			script = append(script, Line{Kind: Insert, Text: midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		script = append(script, Line{Kind: Delete, Text: midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		script = append(script, Line{Kind: Insert, Text: midB[j]})
+	}
+
+	for _, text := range a[len(a)-suffix:] {
+		script = append(script, Line{Kind: Equal, Text: text})
+	}
+
+	return script
+}
+
+// Unified renders the changes between old and new in the unified
+// format, with the given number of context lines around each hunk.
+// It returns an empty string when both contents are equal
+func Unified(oldName, newName string, old, new []byte, context int) string {
+	script := Lines(SplitLines(old), SplitLines(new))
+
+	changed := false
+	for _, line := range script {
+		if line.Kind != Equal {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(script); {
+		// Find the next change and extend the hunk while the gap
+		// to the following change fits within the context
+		first := start
+		for first < len(script) && script[first].Kind == Equal {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+		last := first
+		for next := first; next < len(script); next++ {
+			if script[next].Kind == Equal {
+				continue
+			}
+			if next-last > 2*context {
+				break
+			}
+			last = next
+		}
+
+		from := max(first-context, start)
+		to := min(last+context+1, len(script))
+
+		oldStart, newStart := 1, 1
+		for _, line := range script[:from] {
+			if line.Kind != Insert {
+				oldStart++
+			}
+			if line.Kind != Delete {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, line := range script[from:to] {
+			if line.Kind != Insert {
+				oldCount++
+			}
+			if line.Kind != Delete {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, line := range script[from:to] {
+			switch line.Kind {
+			case Equal:
+				sb.WriteString(" ")
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(line.Text)
+			sb.WriteString("\n")
+		}
+
+		start = to
+	}
+
+	return sb.String()
+}
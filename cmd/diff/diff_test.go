@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	a := []string{"package main", "", "func main() {", "}", ""}
+	b := []string{"package main", "", "import \"fmt\"", "", "func main() {", "\tfmt.Println()", "}", ""}
+
+	var rebuiltA, rebuiltB []string
+	inserted := 0
+	for _, line := range Lines(a, b) {
+		if line.Kind != Insert {
+			rebuiltA = append(rebuiltA, line.Text)
+		}
+		if line.Kind != Delete {
+			rebuiltB = append(rebuiltB, line.Text)
+		}
+		if line.Kind == Insert {
+			inserted++
+		}
+	}
+
+	if strings.Join(rebuiltA, "\n") != strings.Join(a, "\n") {
+		t.Errorf("edit script does not rebuild the old input: %q", rebuiltA)
+	}
+	if strings.Join(rebuiltB, "\n") != strings.Join(b, "\n") {
+		t.Errorf("edit script does not rebuild the new input: %q", rebuiltB)
+	}
+	if inserted != 3 {
+		t.Errorf("expected 3 inserted lines, got %d", inserted)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	if out := Unified("a", "b", []byte("same\n"), []byte("same\n"), 3); out != "" {
+		t.Errorf("expected no diff for equal content, got %q", out)
+	}
+
+	expected := "--- /dev/null\n+++ main.go\n@@ -0,0 +1,2 @@\n+package main\n+\n"
+	if out := Unified("/dev/null", "main.go", nil, []byte("package main\n\n"), 3); out != expected {
+		t.Errorf("expected %q, got %q", expected, out)
+	}
+
+	old := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+	new := []byte("1\nTWO\n3\n4\n5\n6\n7\n8\n9\n10\n11\nTWELVE\n")
+	out := Unified("old", "new", old, new, 2)
+	if strings.Count(out, "@@ ") != 2 {
+		t.Errorf("expected two hunks, got:\n%s", out)
+	}
+	if !strings.Contains(out, "@@ -1,4 +1,4 @@\n 1\n-2\n+TWO\n 3\n 4\n") {
+		t.Errorf("unexpected first hunk:\n%s", out)
+	}
+	if !strings.Contains(out, "@@ -10,3 +10,3 @@\n 10\n 11\n-12\n+TWELVE\n") {
+		t.Errorf("unexpected second hunk:\n%s", out)
+	}
+}
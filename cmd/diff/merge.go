@@ -0,0 +1,121 @@
+package diff
+
+import "slices"
+
+// Labels name the sides of a merge in the conflict markers
+type Labels struct {
+	Ours   string
+	Theirs string
+}
+
+// Merge combines the changes made to base in ours and in theirs. Changes
+// made by a single side, or identically by both, are applied; overlapping
+// changes are kept between conflict markers. It returns the merged lines
+// and the number of conflicts
+func Merge(base, ours, theirs []string, labels Labels) ([]string, int) {
+	matchOurs := matches(base, ours)
+	matchTheirs := matches(base, theirs)
+
+	var merged []string
+	conflicts := 0
+
+	o, a, b := 0, 0, 0
+	for o < len(base) || a < len(ours) || b < len(theirs) {
+		// Copy the lines kept unchanged by both sides
+		stable := 0
+		for o+stable < len(base) && matchOurs[o+stable] == a+stable && matchTheirs[o+stable] == b+stable {
+			stable++
+		}
+		if stable > 0 {
+			merged = append(merged, base[o:o+stable]...)
+			o, a, b = o+stable, a+stable, b+stable
+			continue
+		}
+
+		// The chunk changed by either side ends at the next
+		// base line that both sides kept
+		end := o
+		for end < len(base) && (matchOurs[end] < 0 || matchTheirs[end] < 0) {
+			end++
+		}
+		endOurs, endTheirs := len(ours), len(theirs)
+		if end < len(base) {
+			endOurs, endTheirs = matchOurs[end], matchTheirs[end]
+		}
+
+		baseChunk, oursChunk, theirsChunk := base[o:end], ours[a:endOurs], theirs[b:endTheirs]
+		switch {
+		case slices.Equal(oursChunk, baseChunk), slices.Equal(oursChunk, theirsChunk):
+			merged = append(merged, theirsChunk...)
+		case slices.Equal(theirsChunk, baseChunk):
+			merged = append(merged, oursChunk...)
+		default:
+			merged = appendConflict(merged, oursChunk, theirsChunk, labels)
+			conflicts++
+		}
+		o, a, b = end, endOurs, endTheirs
+	}
+
+	return merged, conflicts
+}
+
+// MergeWithoutBase combines ours and theirs when the version they
+// both derive from is unknown. Lines present on both sides are
+// kept, and every difference is left between conflict markers
+func MergeWithoutBase(ours, theirs []string, labels Labels) ([]string, int) {
+	var merged, oursChunk, theirsChunk []string
+	conflicts := 0
+
+	flush := func() {
+		if len(oursChunk) > 0 || len(theirsChunk) > 0 {
+			merged = appendConflict(merged, oursChunk, theirsChunk, labels)
+			conflicts++
+		}
+		oursChunk, theirsChunk = nil, nil
+	}
+
+	for _, line := range Lines(ours, theirs) {
+		switch line.Kind {
+		case Equal:
+			flush()
+			merged = append(merged, line.Text)
+		case Delete:
+			oursChunk = append(oursChunk, line.Text)
+		case Insert:
+			theirsChunk = append(theirsChunk, line.Text)
+		}
+	}
+	flush()
+
+	return merged, conflicts
+}
+
+// matches maps every line of base to the index of the
+// same line in other, or -1 when other dropped it
+func matches(base, other []string) []int {
+	match := make([]int, len(base))
+	i, j := 0, 0
+	for _, line := range Lines(base, other) {
+		switch line.Kind {
+		case Equal:
+			match[i] = j
+			i++
+			j++
+		case Delete:
+			match[i] = -1
+			i++
+		case Insert:
+			j++
+		}
+	}
+
+	return match
+}
+
+func appendConflict(merged, ours, theirs []string, labels Labels) []string {
+	merged = append(merged, "<<<<<<< "+labels.Ours)
+	merged = append(merged, ours...)
+	merged = append(merged, "=======")
+	merged = append(merged, theirs...)
+	return append(merged, ">>>>>>> "+labels.Theirs)
+}
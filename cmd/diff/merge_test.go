@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+var testLabels = Labels{Ours: "current", Theirs: "go-blueprint"}
+
+func TestMerge(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+
+	tests := []struct {
+		name      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{
+			name:   "unchanged",
+			ours:   base,
+			theirs: base,
+			want:   base,
+		},
+		{
+			name:   "only ours",
+			ours:   "a\nB\nc\nd\ne\n",
+			theirs: base,
+			want:   "a\nB\nc\nd\ne\n",
+		},
+		{
+			name:   "only theirs",
+			ours:   base,
+			theirs: "a\nb\nc\nd\ne\nf\n",
+			want:   "a\nb\nc\nd\ne\nf\n",
+		},
+		{
+			name:   "separate changes",
+			ours:   "a\nB\nc\nd\ne\n",
+			theirs: "a\nb\nc\nD\ne\n",
+			want:   "a\nB\nc\nD\ne\n",
+		},
+		{
+			name:   "same change",
+			ours:   "a\nb\nC\nd\ne\n",
+			theirs: "a\nb\nC\nd\ne\n",
+			want:   "a\nb\nC\nd\ne\n",
+		},
+		{
+			name:      "conflict",
+			ours:      "a\nb\nours\nd\ne\n",
+			theirs:    "a\nb\ntheirs\nd\ne\n",
+			want:      "a\nb\n<<<<<<< current\nours\n=======\ntheirs\n>>>>>>> go-blueprint\nd\ne\n",
+			conflicts: 1,
+		},
+		{
+			name:   "deletion and insertion",
+			ours:   "b\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\ne\nf\ng\n",
+			want:   "b\nc\nd\ne\nf\ng\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := Merge(SplitLines([]byte(base)), SplitLines([]byte(tt.ours)), SplitLines([]byte(tt.theirs)), testLabels)
+			if got := strings.Join(merged, "\n") + "\n"; got != tt.want {
+				t.Errorf("Merge() = %q, want %q", got, tt.want)
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("Merge() reported %d conflicts, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeWithoutBase(t *testing.T) {
+	merged, conflicts := MergeWithoutBase(SplitLines([]byte("a\nours\nc\n")), SplitLines([]byte("a\ntheirs\nc\nd\n")), testLabels)
+
+	want := "a\n<<<<<<< current\nours\n=======\ntheirs\n>>>>>>> go-blueprint\nc\n<<<<<<< current\n=======\nd\n>>>>>>> go-blueprint"
+	if got := strings.Join(merged, "\n"); got != want {
+		t.Errorf("MergeWithoutBase() = %q, want %q", got, want)
+	}
+	if conflicts != 2 {
+		t.Errorf("MergeWithoutBase() reported %d conflicts, want 2", conflicts)
+	}
+}
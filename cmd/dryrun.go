@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/diff"
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/program"
+)
+
+// printDryRun prints the tree of files rendered into memory along
+// with their sizes, and the external commands that were skipped.
+// When showDiff is set, every file is also printed as a diff
+// against the current content of the target directory
+func printDryRun(project *program.Project, memory *filesystem.Memory, showDiff bool) {
+	paths := memory.Paths()
+	for i, path := range paths {
+		paths[i] = filepath.FromSlash(path)
+	}
+
+	fmt.Println(endingMsgStyle.Render(fmt.Sprintf("Dry run: %d files would be created in %s\n", len(paths), project.AbsolutePath)))
+
+	printed := make(map[string]bool)
+	for _, path := range paths {
+		rel, err := filepath.Rel(project.AbsolutePath, path)
+		if err != nil {
+			rel = path
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+
+		// Print every parent directory the first time it shows up
+		for i := range parts[:len(parts)-1] {
+			dir := strings.Join(parts[:i+1], "/")
+			if !printed[dir] {
+				printed[dir] = true
+				fmt.Printf("%s%s/\n", strings.Repeat("    ", i), parts[i])
+			}
+		}
+
+		content, err := memory.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		size := formatSize(len(content))
+		fmt.Printf("%s%s (%s)\n", strings.Repeat("    ", len(parts)-1), parts[len(parts)-1], size)
+	}
+
+	if len(project.SkippedCommands) > 0 {
+		fmt.Println(endingMsgStyle.Render("\nCommands that would run:"))
+		for _, command := range project.SkippedCommands {
+			fmt.Printf(" • %s\n", command)
+		}
+	}
+
+	if !showDiff {
+		return
+	}
+
+	fmt.Println()
+	for _, path := range paths {
+		oldName := path
+		current, err := os.ReadFile(path)
+		if err != nil {
+			oldName = "/dev/null"
+			current = nil
+		}
+
+		rendered, err := memory.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		fmt.Print(diff.Unified(oldName, path, current, rendered, 3))
+	}
+}
+
+// formatSize returns a human readable representation of
+// a file size in bytes
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
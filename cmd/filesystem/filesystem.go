@@ -0,0 +1,200 @@
+// Package filesystem provides the file systems
+// a Project can be generated into
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A FileSystem is the sink every generated file
+// and directory is written to
+type FileSystem interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	ReadFile(name string) ([]byte, error)
+	Remove(name string) error
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// OS is a FileSystem backed by the
+// operating system
+type OS struct{}
+
+func (OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (OS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (OS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (OS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// Memory is a FileSystem that keeps everything in memory.
+// It is safe for concurrent use
+type Memory struct {
+	mu    sync.RWMutex
+	files map[string]*memoryFile
+	dirs  map[string]fs.FileMode
+}
+
+type memoryFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemory returns an empty in-memory FileSystem
+func NewMemory() *Memory {
+	return &Memory{
+		files: make(map[string]*memoryFile),
+		dirs:  make(map[string]fs.FileMode),
+	}
+}
+
+// clean normalizes name so that both OS specific and
+// slash separated paths address the same entry
+func clean(name string) string {
+	return path.Clean(filepath.ToSlash(name))
+}
+
+func (m *Memory) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.mkdirAll(clean(name), perm)
+}
+
+func (m *Memory) mkdirAll(name string, perm fs.FileMode) error {
+	for dir := name; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, ok := m.files[dir]; ok {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+		}
+		if _, ok := m.dirs[dir]; !ok {
+			m.dirs[dir] = perm
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	if _, ok := m.dirs[name]; ok {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	}
+	// Like os.WriteFile, the parent directory has to be there. Generation
+	// always creates it beforehand, keeping both implementations honest
+	if dir := path.Dir(name); dir != "." && dir != "/" {
+		if _, ok := m.dirs[dir]; !ok {
+			return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+	}
+
+	content := make([]byte, len(data))
+	copy(content, data)
+	m.files[name] = &memoryFile{data: content, mode: perm, modTime: time.Now()}
+
+	return nil
+}
+
+func (m *Memory) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	file, ok := m.files[clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	content := make([]byte, len(file.data))
+	copy(content, file.data)
+	return content, nil
+}
+
+func (m *Memory) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+	if _, ok := m.files[name]; ok {
+		delete(m.files, name)
+		return nil
+	}
+	if _, ok := m.dirs[name]; ok {
+		for other := range m.files {
+			if strings.HasPrefix(other, name+"/") {
+				return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+			}
+		}
+		delete(m.dirs, name)
+		return nil
+	}
+
+	return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *Memory) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = clean(name)
+	if file, ok := m.files[name]; ok {
+		return fileInfo{name: path.Base(name), size: int64(len(file.data)), mode: file.mode, modTime: file.modTime}, nil
+	}
+	if perm, ok := m.dirs[name]; ok {
+		return fileInfo{name: path.Base(name), mode: fs.ModeDir | perm}, nil
+	}
+
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// Paths returns the slash separated path of every
+// file held in memory, sorted alphabetically
+func (m *Memory) Paths() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	paths := make([]string, 0, len(m.files))
+	for name := range m.files {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// fileInfo describes a file or a directory of a Memory FileSystem
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi fileInfo) Sys() any           { return nil }
@@ -0,0 +1,55 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	memory := NewMemory()
+
+	if err := memory.WriteFile("/project/main.go", []byte("package main"), 0o644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected writing without a parent directory to fail, got %v", err)
+	}
+
+	if err := memory.MkdirAll("/project/cmd/api", 0o751); err != nil {
+		t.Fatalf("could not create directory: %v", err)
+	}
+	if err := memory.WriteFile("/project/cmd/api/main.go", []byte("package main"), 0o644); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+	if err := memory.WriteFile("/project/go.mod", []byte("module project"), 0o644); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	content, err := memory.ReadFile("/project/cmd/api/main.go")
+	if err != nil || string(content) != "package main" {
+		t.Errorf("expected file content %q, got %q (%v)", "package main", content, err)
+	}
+
+	info, err := memory.Stat("/project/cmd")
+	if err != nil || !info.IsDir() {
+		t.Errorf("expected /project/cmd to be a directory, got %v (%v)", info, err)
+	}
+	info, err = memory.Stat("/project/go.mod")
+	if err != nil || info.IsDir() || info.Size() != int64(len("module project")) {
+		t.Errorf("unexpected file info for /project/go.mod: %v (%v)", info, err)
+	}
+
+	expected := []string{"/project/cmd/api/main.go", "/project/go.mod"}
+	if paths := memory.Paths(); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+
+	if err := memory.Remove("/project/go.mod"); err != nil {
+		t.Errorf("could not remove file: %v", err)
+	}
+	if _, err := memory.Stat("/project/go.mod"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected removed file to be gone, got %v", err)
+	}
+	if err := memory.Remove("/project/go.mod"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected removing a missing file to fail, got %v", err)
+	}
+}
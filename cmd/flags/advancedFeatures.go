@@ -14,9 +14,18 @@ const (
 	Tailwind          string = "tailwind"
 	React             string = "react"
 	Docker            string = "docker"
+	Migrations        string = "migrations"
+	Sqlc              string = "sqlc"
+	Grpc              string = "grpc"
+	Graphql           string = "graphql"
+	Auth              string = "auth"
+	Otel              string = "otel"
+	Metrics           string = "metrics"
+	Kubernetes        string = "kubernetes"
+	Helm              string = "helm"
 )
 
-var AllowedAdvancedFeatures = []string{string(React), string(Htmx), string(GoProjectWorkflow), string(Websocket), string(Tailwind), string(Docker)}
+var AllowedAdvancedFeatures = []string{string(React), string(Htmx), string(GoProjectWorkflow), string(Websocket), string(Tailwind), string(Docker), string(Migrations), string(Sqlc), string(Grpc), string(Graphql), string(Auth), string(Otel), string(Metrics), string(Kubernetes), string(Helm)}
 
 func (f AdvancedFeatures) String() string {
 	return strings.Join(f, ",")
@@ -0,0 +1,122 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// A Config declares the options of a project in a YAML or JSON
+// file, as an alternative to the flags of the create command
+type Config struct {
+	Name      string   `yaml:"name" json:"name"`
+	Framework string   `yaml:"framework" json:"framework"`
+	Driver    string   `yaml:"driver" json:"driver"`
+	ORM       string   `yaml:"orm" json:"orm"`
+	Features  []string `yaml:"features" json:"features"`
+	Git       string   `yaml:"git" json:"git"`
+	OpenAPI   string   `yaml:"openapi" json:"openapi"`
+}
+
+// LoadConfig reads and validates the config file at name. Files
+// ending in .json are read as JSON, anything else as YAML
+func LoadConfig(name string) (*Config, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", name, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", name, err)
+	}
+
+	// The OpenAPI document is found next to the config
+	if config.OpenAPI != "" && !filepath.IsAbs(config.OpenAPI) {
+		config.OpenAPI = filepath.Join(filepath.Dir(name), config.OpenAPI)
+	}
+
+	return config, nil
+}
+
+// Validate checks every value of the config against the values
+// allowed for the matching flag, and reports all invalid ones
+func (c *Config) Validate() error {
+	var errs []error
+
+	check := func(key, value string, allowed []string) {
+		if value != "" && !slices.Contains(allowed, value) {
+			errs = append(errs, fmt.Errorf("%s '%s' is not supported. Allowed values: %s", key, value, strings.Join(allowed, ", ")))
+		}
+	}
+
+	check("framework", c.Framework, AllowedProjectTypes)
+	if c.Driver != "" {
+		for _, driver := range strings.Split(c.Driver, ",") {
+			check("driver", strings.TrimSpace(driver), AllowedDBDrivers)
+		}
+	}
+	check("orm", c.ORM, AllowedORMs)
+	for _, feature := range c.Features {
+		check("feature", feature, AllowedAdvancedFeatures)
+	}
+	check("git", c.Git, AllowedGitsOptions)
+
+	return errors.Join(errs...)
+}
+
+// Apply sets the flags of the create command from the config.
+// Flags given on the command line take precedence and are kept
+func (c *Config) Apply(flagSet *pflag.FlagSet) error {
+	values := map[string]string{
+		"name":      c.Name,
+		"framework": c.Framework,
+		"driver":    c.Driver,
+		"orm":       c.ORM,
+		"git":       c.Git,
+		"openapi":   c.OpenAPI,
+	}
+	for name, value := range values {
+		if value == "" || flagSet.Changed(name) {
+			continue
+		}
+		if err := flagSet.Set(name, value); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Features) > 0 && !flagSet.Changed("feature") {
+		for _, feature := range c.Features {
+			if err := flagSet.Set("feature", feature); err != nil {
+				return err
+			}
+		}
+		// Features are only applied in advanced mode
+		if err := flagSet.Set("advanced", "true"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
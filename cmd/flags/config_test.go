@@ -0,0 +1,110 @@
+package flags_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/spf13/pflag"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	want := flags.Config{Name: "svc", Framework: "acme", Driver: "acmesql", ORM: "acmeorm", Features: []string{"docker"}, Git: "skip"}
+
+	cases := map[string]string{
+		"blueprint.yaml": "name: svc\nframework: acme\ndriver: acmesql\norm: acmeorm\nfeatures: [docker]\ngit: skip\n",
+		"blueprint.json": `{"name": "svc", "framework": "acme", "driver": "acmesql", "orm": "acmeorm", "features": ["docker"], "git": "skip"}`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			config, err := flags.LoadConfig(writeConfig(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Name != want.Name || config.Framework != want.Framework || config.Driver != want.Driver || config.ORM != want.ORM ||
+				config.Git != want.Git || !slices.Equal(config.Features, want.Features) {
+				t.Errorf("LoadConfig() = %+v, want %+v", *config, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		errs    []string
+	}{
+		"values.yaml":  {"framework: rocket\ndriver: acmesql\nfeatures: [docker, nope]\n", []string{"framework 'rocket'", "feature 'nope'"}},
+		"drivers.yaml": {"driver: acmesql,nope\n", []string{"driver 'nope'"}},
+		"orm.yaml":     {"orm: hibernate\n", []string{"orm 'hibernate'"}},
+		"unknown.yaml": {"framwork: acme\n", []string{"framwork"}},
+		"unknown.json": {`{"drivers": "acmesql"}`, []string{"drivers"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := flags.LoadConfig(writeConfig(t, name, tc.content))
+			if err == nil {
+				t.Fatal("LoadConfig() succeeded, want an error")
+			}
+			for _, want := range tc.errs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadConfig() error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	var framework flags.Framework
+	var database flags.Database
+	var features flags.AdvancedFeatures
+	var git flags.Git
+	flagSet := pflag.NewFlagSet("create", pflag.ContinueOnError)
+	flagSet.String("name", "", "")
+	flagSet.Var(&framework, "framework", "")
+	flagSet.Var(&database, "driver", "")
+	flagSet.Bool("advanced", false, "")
+	flagSet.Var(&features, "feature", "")
+	flagSet.Var(&git, "git", "")
+
+	if err := flagSet.Parse([]string{"--framework", "acme/router"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &flags.Config{Name: "svc", Framework: "acme", Driver: "acmekv", Features: []string{"docker", "htmx"}}
+	if err := config.Apply(flagSet); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := flagSet.Lookup("name").Value.String(); got != "svc" {
+		t.Errorf("name = %q, want svc", got)
+	}
+	if framework != "acme/router" {
+		t.Errorf("framework = %q, want the command line value acme/router", framework)
+	}
+	if database != "acmekv" {
+		t.Errorf("driver = %q, want acmekv", database)
+	}
+	if !slices.Equal(features, flags.AdvancedFeatures{"docker", "htmx"}) {
+		t.Errorf("features = %v, want [docker htmx]", features)
+	}
+	if advanced, _ := flagSet.GetBool("advanced"); !advanced {
+		t.Error("advanced was not enabled by the config features")
+	}
+	if git != "" {
+		t.Errorf("git = %q, want it left unset", git)
+	}
+}
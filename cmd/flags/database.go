@@ -2,14 +2,17 @@ package flags
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
+// A Database is the driver of a project, or a comma-separated
+// list of drivers for projects using several data stores
 type Database string
 
-// These are all the current databases supported. If you want to add one, you
-// can simply copy and paste a line here. Do not forget to also add it into the
-// AllowedDBDrivers slice too!
+// These are the databases built into Blueprint. Drivers are
+// made available by program.RegisterDriver, which adds them
+// to AllowedDBDrivers
 const (
 	MySql    Database = "mysql"
 	Postgres Database = "postgres"
@@ -20,7 +23,8 @@ const (
 	None     Database = "none"
 )
 
-var AllowedDBDrivers = []string{string(MySql), string(Postgres), string(Sqlite), string(Mongo), string(Redis), string(Scylla), string(None)}
+// AllowedDBDrivers lists the registered drivers, followed by None
+var AllowedDBDrivers = []string{string(None)}
 
 func (f Database) String() string {
 	return string(f)
@@ -31,14 +35,35 @@ func (f *Database) Type() string {
 }
 
 func (f *Database) Set(value string) error {
-	// Contains isn't available in 1.20 yet
-	// if AllowedDBDrivers.Contains(value) {
-	for _, database := range AllowedDBDrivers {
-		if database == value {
-			*f = Database(value)
-			return nil
+	var selected []string
+	for _, database := range strings.Split(value, ",") {
+		database = strings.TrimSpace(database)
+		if !slices.Contains(AllowedDBDrivers, database) {
+			return fmt.Errorf("Database to use. Allowed values: %s", strings.Join(AllowedDBDrivers, ", "))
 		}
+		if slices.Contains(selected, database) {
+			return fmt.Errorf("database %s is selected twice", database)
+		}
+		selected = append(selected, database)
+	}
+	if len(selected) > 1 && slices.Contains(selected, string(None)) {
+		return fmt.Errorf("%s cannot be combined with other databases", None)
 	}
 
-	return fmt.Errorf("Database to use. Allowed values: %s", strings.Join(AllowedDBDrivers, ", "))
+	*f = Database(strings.Join(selected, ","))
+	return nil
+}
+
+// Split returns the drivers of the list, none
+// of them when the database is None or empty
+func (f Database) Split() []Database {
+	if f == None || f == "" {
+		return nil
+	}
+
+	var drivers []Database
+	for _, driver := range strings.Split(string(f), ",") {
+		drivers = append(drivers, Database(driver))
+	}
+	return drivers
 }
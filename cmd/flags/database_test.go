@@ -0,0 +1,30 @@
+package flags_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+func TestDatabaseSet(t *testing.T) {
+	var database flags.Database
+	if err := database.Set("acmesql, acmekv"); err != nil {
+		t.Fatal(err)
+	}
+	if database != "acmesql,acmekv" {
+		t.Errorf("database = %q, want acmesql,acmekv", database)
+	}
+	if got := database.Split(); !slices.Equal(got, []flags.Database{"acmesql", "acmekv"}) {
+		t.Errorf("Split() = %v, want [acmesql acmekv]", got)
+	}
+	if got := flags.None.Split(); got != nil {
+		t.Errorf("expected none to have no drivers, got %v", got)
+	}
+
+	for _, value := range []string{"nope", "acmesql,nope", "acmekv,acmekv", "acmesql,none", ""} {
+		if err := database.Set(value); err == nil {
+			t.Errorf("Set(%q) succeeded, want an error", value)
+		}
+	}
+}
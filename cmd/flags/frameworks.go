@@ -7,9 +7,8 @@ import (
 
 type Framework string
 
-// These are all the current frameworks supported. If you want to add one, you
-// can simply copy and paste a line here. Do not forget to also add it into the
-// AllowedProjectTypes slice too!
+// These are the frameworks built into Blueprint. Frameworks are added
+// with program.Register, which a constant is not needed for
 const (
 	Chi             Framework = "chi"
 	Gin             Framework = "gin"
@@ -20,7 +19,9 @@ const (
 	Echo            Framework = "echo"
 )
 
-var AllowedProjectTypes = []string{string(Chi), string(Gin), string(Fiber), string(GorillaMux), string(HttpRouter), string(StandardLibrary), string(Echo)}
+// AllowedProjectTypes lists the names of the registered
+// frameworks. It is filled by program.Register
+var AllowedProjectTypes []string
 
 func (f Framework) String() string {
 	return string(f)
@@ -0,0 +1,18 @@
+package flags_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+// TestMain allows stub frameworks, drivers and ORMs. The real ones
+// are registered by the program package, which flags does not know
+func TestMain(m *testing.M) {
+	flags.AllowedProjectTypes = []string{"acme", "acme/router"}
+	flags.AllowedDBDrivers = []string{"acmesql", "acmekv", string(flags.None)}
+	flags.AllowedORMs = []string{"acmeorm", string(flags.NoORM)}
+
+	os.Exit(m.Run())
+}
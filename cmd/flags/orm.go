@@ -0,0 +1,42 @@
+package flags
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// An ORM is the library the services of the SQL
+// databases of a project query them with
+type ORM string
+
+// These are the ORMs built into Blueprint. ORMs are made
+// available by program.RegisterORM, which adds them to
+// AllowedORMs. With NoORM, the services use database/sql
+const (
+	Gorm  ORM = "gorm"
+	Ent   ORM = "ent"
+	Bun   ORM = "bun"
+	Sqlx  ORM = "sqlx"
+	NoORM ORM = "none"
+)
+
+// AllowedORMs lists the registered ORMs, followed by NoORM
+var AllowedORMs = []string{string(NoORM)}
+
+func (f ORM) String() string {
+	return string(f)
+}
+
+func (f *ORM) Type() string {
+	return "ORM"
+}
+
+func (f *ORM) Set(value string) error {
+	if !slices.Contains(AllowedORMs, value) {
+		return fmt.Errorf("ORM to use. Allowed values: %s", strings.Join(AllowedORMs, ", "))
+	}
+
+	*f = ORM(value)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/program"
+	"github.com/melkeydev/go-blueprint/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.AddCommand(generateResourceCmd)
+
+	generateResourceCmd.Flags().String("fields", "", fmt.Sprintf("Fields of the resource, as name:type pairs separated by commas. Allowed types: %s", strings.Join(program.AllowedFieldTypes, ", ")))
+	generateResourceCmd.Flags().String("driver", "", "Database storing the resource, for projects using several. Defaults to the first one")
+	generateResourceCmd.Flags().String("path", ".", "Path of the project generated by go-blueprint")
+	addTemplateDirFlag(generateResourceCmd)
+
+	utils.RegisterStaticCompletions(generateResourceCmd, "driver", flags.AllowedDBDrivers)
+}
+
+// generateCmd defines the "generate" command for the CLI
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate code in an existing Blueprint project",
+}
+
+// generateResourceCmd defines the "generate resource" command for the CLI
+var generateResourceCmd = &cobra.Command{
+	Use:   "resource <name>",
+	Short: "Generate the CRUD endpoints of a resource",
+	Long: `Generate the model, repository, handlers and tests of a resource in a project previously generated with Go Blueprint.
+The routes of the resource are registered in internal/server/routes.go, and its table is created by a migration when the project has the migrations feature.`,
+	Example: "go-blueprint generate resource post --fields title:string,views:int,published:bool",
+	Args:    cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath, err := filepath.Abs(cmd.Flag("path").Value.String())
+		cobra.CheckErr(err)
+		cobra.CheckErr(loadTemplateOverlays(cmd))
+
+		project, err := program.DetectProject(projectPath)
+		cobra.CheckErr(err)
+
+		resource, err := program.ParseResource(args[0], cmd.Flag("fields").Value.String())
+		cobra.CheckErr(err)
+
+		driver := flags.Database(cmd.Flag("driver").Value.String())
+		plan, err := project.PlanResource(projectPath, resource, driver)
+		cobra.CheckErr(err)
+
+		if err := plan.Apply(); err != nil {
+			log.Printf("Problem generating resource.")
+			cobra.CheckErr(err)
+		}
+
+		fmt.Println(endingMsgStyle.Render(fmt.Sprintf("\nGenerated the %s resource:", resource.Name)))
+		for _, file := range plan.Files {
+			fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• %s (created)", file.Path)))
+		}
+		if plan.Routes != nil {
+			fmt.Println(endingMsgStyle.Render(fmt.Sprintf("• %s (updated)", plan.Routes.Path)))
+		} else {
+			fmt.Println(endingMsgStyle.Render(fmt.Sprintf("\nCould not update internal/server/routes.go: %v", plan.RoutesError)))
+			fmt.Println(endingMsgStyle.Render("Register the routes of the resource in RegisterRoutes by hand:"))
+			fmt.Println(endingMsgStyle.Render(plan.Registration))
+		}
+
+		for _, file := range plan.Files {
+			if strings.HasSuffix(file.Path, ".up.sql") {
+				fmt.Println(endingMsgStyle.Render("\n• Create the table of the resource by running `make migrate-up`"))
+				break
+			}
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+			fmt.Println(tipMsgStyle.Render("\nReview and commit the changes with git"))
+		}
+	},
+}
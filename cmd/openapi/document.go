@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the subset of an OpenAPI 3 document Blueprint reads
+type document struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title string `yaml:"title"`
+	} `yaml:"info"`
+	Paths      orderedMap[*pathItem] `yaml:"paths"`
+	Components struct {
+		Schemas       orderedMap[*schema]      `yaml:"schemas"`
+		Parameters    orderedMap[*parameter]   `yaml:"parameters"`
+		RequestBodies orderedMap[*requestBody] `yaml:"requestBodies"`
+		Responses     orderedMap[*response]    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Post       *operation   `yaml:"post"`
+	Put        *operation   `yaml:"put"`
+	Patch      *operation   `yaml:"patch"`
+	Delete     *operation   `yaml:"delete"`
+	Head       *operation   `yaml:"head"`
+	Options    *operation   `yaml:"options"`
+}
+
+func (p *pathItem) operation(method string) *operation {
+	switch method {
+	case "get":
+		return p.Get
+	case "post":
+		return p.Post
+	case "put":
+		return p.Put
+	case "patch":
+		return p.Patch
+	case "delete":
+		return p.Delete
+	case "head":
+		return p.Head
+	case "options":
+		return p.Options
+	}
+	return nil
+}
+
+type operation struct {
+	OperationID string                `yaml:"operationId"`
+	Summary     string                `yaml:"summary"`
+	Parameters  []*parameter          `yaml:"parameters"`
+	RequestBody *requestBody          `yaml:"requestBody"`
+	Responses   orderedMap[*response] `yaml:"responses"`
+}
+
+type parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *schema `yaml:"schema"`
+}
+
+type requestBody struct {
+	Ref     string                 `yaml:"$ref"`
+	Content orderedMap[*mediaType] `yaml:"content"`
+}
+
+type response struct {
+	Ref     string                 `yaml:"$ref"`
+	Content orderedMap[*mediaType] `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref                  string              `yaml:"$ref"`
+	Type                 schemaType          `yaml:"type"`
+	Format               string              `yaml:"format"`
+	Description          string              `yaml:"description"`
+	Properties           orderedMap[*schema] `yaml:"properties"`
+	Required             []string            `yaml:"required"`
+	Items                *schema             `yaml:"items"`
+	AdditionalProperties yaml.Node           `yaml:"additionalProperties"`
+	AllOf                []*schema           `yaml:"allOf"`
+	OneOf                []*schema           `yaml:"oneOf"`
+	AnyOf                []*schema           `yaml:"anyOf"`
+}
+
+// schemaType is the type of a schema, written as a single
+// name, or as a list of names in OpenAPI 3.1 such as
+// [string, "null"]. Only the first non null name is kept
+type schemaType string
+
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	var names []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		names = []string{node.Value}
+	case yaml.SequenceNode:
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("line %d: invalid schema type", node.Line)
+	}
+
+	for _, name := range names {
+		if name != "null" {
+			*t = schemaType(name)
+			break
+		}
+	}
+	return nil
+}
+
+// orderedMap is a YAML mapping that keeps the order of its keys,
+// so that generated code follows the order of the document
+type orderedMap[T any] struct {
+	keys   []string
+	values map[string]T
+}
+
+func (m *orderedMap[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+
+	m.values = make(map[string]T)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		m.keys = append(m.keys, key)
+		m.values[key] = value
+	}
+	return nil
+}
+
+// componentName returns the name of the component a local
+// reference such as #/components/schemas/Pet points to
+func componentName(ref, kind string) (string, error) {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference %q, only %s* references are supported", ref, prefix)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
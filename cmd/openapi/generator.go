@@ -0,0 +1,456 @@
+package openapi
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// generator turns the schemas and operations of a
+// document into Go types and Operations
+type generator struct {
+	doc     *document
+	types   map[string]bool // Type names already taken
+	defined []Type
+	err     error
+}
+
+// defineType adds the Go type generated from the schema under name
+func (g *generator) defineType(name string, s *schema) {
+	g.types[name] = true
+	// Reserve the position of the type, so that it is
+	// generated before the inline types of its fields
+	index := len(g.defined)
+	g.defined = append(g.defined, Type{Name: name})
+
+	t := Type{Name: name, Description: oneLine(s.Description)}
+	if len(s.Properties.keys) == 0 {
+		t.Underlying = g.goType(name+"Item", s)
+		if t.Underlying == name {
+			t.Underlying = "any"
+		}
+	}
+
+	var fieldNames []string
+	for _, property := range s.Properties.keys {
+		fieldName := unique(goName(property), fieldNames)
+		fieldNames = append(fieldNames, fieldName)
+		t.Fields = append(t.Fields, Field{
+			Name:     fieldName,
+			JSONName: property,
+			GoType:   g.goType(name+fieldName, s.Properties.values[property]),
+			Required: slices.Contains(s.Required, property),
+		})
+	}
+
+	g.defined[index] = t
+}
+
+// goType returns the Go type of the schema. Inline object
+// schemas are defined as types named after their context
+func (g *generator) goType(context string, s *schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		name, err := componentName(s.Ref, "schemas")
+		if err != nil {
+			g.fail(err)
+			return "any"
+		}
+		if _, ok := g.doc.Components.Schemas.values[name]; !ok {
+			g.fail(errMissing(s.Ref))
+			return "any"
+		}
+		return goName(name)
+	}
+	if len(s.AllOf) == 1 && len(s.Properties.keys) == 0 {
+		return g.goType(context, s.AllOf[0])
+	}
+	if len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return "any"
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "time.Time"
+		case "binary":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int32" || s.Format == "int64" {
+			return s.Format
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(context+"Item", s.Items)
+	case "object", "":
+		if len(s.Properties.keys) > 0 {
+			name := unique(context, mapKeys(g.types))
+			g.defineType(name, s)
+			return name
+		}
+		if s.AdditionalProperties.Kind == yaml.MappingNode {
+			values := &schema{}
+			if err := s.AdditionalProperties.Decode(values); err != nil {
+				g.fail(err)
+				return "any"
+			}
+			return "map[string]" + g.goType(context+"Value", values)
+		}
+		if s.Type == "object" {
+			return "map[string]any"
+		}
+	}
+
+	return "any"
+}
+
+// operation builds the Operation of a method on a path, with the
+// parameters shared by the path and the ones of the operation
+func (g *generator) operation(method, path string, shared []*parameter, op *operation) (Operation, error) {
+	operation := Operation{
+		Handler: goName(op.OperationID),
+		Method:  strings.ToUpper(method),
+		Path:    path,
+		Summary: strings.TrimSuffix(oneLine(op.Summary), "."),
+		Status:  200,
+	}
+	if op.OperationID == "" {
+		operation.Handler = handlerName(method, path)
+	}
+
+	params := make(map[string]*parameter)
+	var order []string
+	for _, param := range append(slices.Clone(shared), op.Parameters...) {
+		param, err := g.resolveParameter(param)
+		if err != nil {
+			return operation, err
+		}
+		if param == nil {
+			continue
+		}
+		key := param.In + ":" + param.Name
+		if _, ok := params[key]; !ok {
+			order = append(order, key)
+		}
+		params[key] = param
+	}
+	// Path templates without a declared parameter are strings
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		if _, ok := params["path:"+match[1]]; !ok {
+			params["path:"+match[1]] = &parameter{Name: match[1], In: "path", Required: true}
+			order = append(order, "path:"+match[1])
+		}
+	}
+
+	var vars []string
+	for _, key := range order {
+		param := params[key]
+		if param.In != "path" && param.In != "query" {
+			continue
+		}
+		p := Param{
+			Name:     param.Name,
+			GoName:   unique(goVar(param.Name), vars),
+			GoType:   "string",
+			Required: param.Required || param.In == "path",
+		}
+		if param.Schema != nil {
+			p.GoType = g.goType(operation.Handler+goName(param.Name), param.Schema)
+		}
+		vars = append(vars, p.GoName)
+		if param.In == "path" {
+			operation.PathParams = append(operation.PathParams, p)
+		} else {
+			operation.QueryParams = append(operation.QueryParams, p)
+		}
+	}
+
+	if op.RequestBody != nil {
+		body, err := g.resolveRequestBody(op.RequestBody)
+		if err != nil {
+			return operation, err
+		}
+		if s := jsonSchema(body.Content); s != nil {
+			goType := g.goType(operation.Handler+"Request", s)
+			operation.RequestType = g.qualify(goType)
+			operation.SampleBody = g.sample(goType)
+		}
+	}
+
+	codes := slices.Clone(op.Responses.keys)
+	sort.Strings(codes)
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if status, err := strconv.Atoi(code); err == nil {
+			operation.Status = status
+		}
+		resp, err := g.resolveResponse(op.Responses.values[code])
+		if err != nil {
+			return operation, err
+		}
+		if s := jsonSchema(resp.Content); s != nil {
+			operation.ResponseType = g.qualify(g.goType(operation.Handler+"Response", s))
+		}
+		break
+	}
+
+	return operation, g.err
+}
+
+// qualify prefixes the generated types of a Go type with
+// TypesPackage, for use in the handlers
+func (g *generator) qualify(goType string) string {
+	prefix := ""
+	for {
+		switch {
+		case strings.HasPrefix(goType, "[]"):
+			prefix += "[]"
+			goType = goType[2:]
+			continue
+		case strings.HasPrefix(goType, "map[string]"):
+			prefix += "map[string]"
+			goType = goType[len("map[string]"):]
+			continue
+		}
+		break
+	}
+
+	if g.types[goType] {
+		return prefix + TypesPackage + "." + goType
+	}
+	return prefix + goType
+}
+
+// sample returns a JSON document decoding into the Go type
+func (g *generator) sample(goType string) string {
+	// Defined types are followed down to their underlying type,
+	// at most once each in case they refer to each other
+	for i := 0; i <= len(g.defined); i++ {
+		switch {
+		case goType == "[]byte":
+			return `""`
+		case strings.HasPrefix(goType, "[]"):
+			return "[]"
+		case strings.HasPrefix(goType, "map["):
+			return "{}"
+		}
+
+		switch goType {
+		case "string":
+			return `"sample"`
+		case "int", "int32", "int64", "float32", "float64":
+			return "1"
+		case "bool":
+			return "true"
+		case "time.Time":
+			return `"2006-01-02T15:04:05Z"`
+		}
+
+		underlying := ""
+		for _, t := range g.defined {
+			if t.Name == goType {
+				underlying = t.Underlying
+			}
+		}
+		if underlying == "" {
+			break
+		}
+		goType = underlying
+	}
+	return "{}"
+}
+
+func (g *generator) resolveParameter(param *parameter) (*parameter, error) {
+	if param == nil || param.Ref == "" {
+		return param, nil
+	}
+	name, err := componentName(param.Ref, "parameters")
+	if err != nil {
+		return nil, err
+	}
+	resolved, ok := g.doc.Components.Parameters.values[name]
+	if !ok {
+		return nil, errMissing(param.Ref)
+	}
+	return g.resolveParameter(resolved)
+}
+
+func (g *generator) resolveRequestBody(body *requestBody) (*requestBody, error) {
+	if body.Ref == "" {
+		return body, nil
+	}
+	name, err := componentName(body.Ref, "requestBodies")
+	if err != nil {
+		return nil, err
+	}
+	resolved, ok := g.doc.Components.RequestBodies.values[name]
+	if !ok {
+		return nil, errMissing(body.Ref)
+	}
+	return g.resolveRequestBody(resolved)
+}
+
+func (g *generator) resolveResponse(resp *response) (*response, error) {
+	if resp == nil {
+		return &response{}, nil
+	}
+	if resp.Ref == "" {
+		return resp, nil
+	}
+	name, err := componentName(resp.Ref, "responses")
+	if err != nil {
+		return nil, err
+	}
+	resolved, ok := g.doc.Components.Responses.values[name]
+	if !ok {
+		return nil, errMissing(resp.Ref)
+	}
+	return g.resolveResponse(resolved)
+}
+
+func (g *generator) fail(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// jsonSchema returns the schema of the JSON media type of a content
+func jsonSchema(content orderedMap[*mediaType]) *schema {
+	for _, name := range content.keys {
+		if strings.Contains(name, "json") && content.values[name] != nil {
+			return content.values[name].Schema
+		}
+	}
+	return nil
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// handlerName names the handler of an operation without
+// operationId, GET /pets/{id} becoming GetPetsByID
+func handlerName(method, path string) string {
+	name := goName(method)
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if match := pathParamPattern.FindStringSubmatch(segment); match != nil {
+			name += "By" + goName(match[1])
+		} else {
+			name += goName(segment)
+		}
+	}
+	if name == goName(method) {
+		name += "Root"
+	}
+	return name
+}
+
+// initialisms are kept uppercase in Go names, following the Go style
+var initialisms = []string{"API", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "SQL", "URI", "URL", "UUID", "XML"}
+
+// words splits a name written in any case convention into words
+func words(name string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		// A new word starts at an uppercase letter following a
+		// lowercase one, or ending a run of uppercase letters
+		if unicode.IsUpper(r) && len(current) > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// goName returns the exported Go identifier for a name
+// of the document, such as PetID for pet_id
+func goName(name string) string {
+	var sb strings.Builder
+	for _, word := range words(name) {
+		if upper := strings.ToUpper(word); slices.Contains(initialisms, upper) {
+			sb.WriteString(upper)
+			continue
+		}
+		sb.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+	}
+
+	result := sb.String()
+	if result == "" {
+		return "Value"
+	}
+	if unicode.IsDigit(rune(result[0])) {
+		return "N" + result
+	}
+	return result
+}
+
+// goVar returns the unexported Go identifier for a name of the
+// document, avoiding the names used by the generated handlers
+func goVar(name string) string {
+	exported := goName(name)
+	first := words(exported)
+	if len(first) == 0 {
+		return "value"
+	}
+	result := strings.ToLower(first[0]) + exported[len(first[0]):]
+
+	if token.IsKeyword(result) || slices.Contains([]string{"c", "r", "w", "s", "req", "err", "http", "json"}, result) {
+		return result + "Param"
+	}
+	return result
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func errMissing(ref string) error {
+	return fmt.Errorf("reference %s does not exist", ref)
+}
@@ -0,0 +1,266 @@
+// Package openapi reads OpenAPI 3 documents into the operations
+// and types Blueprint generates handlers and structs from
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Spec is an OpenAPI document reduced to
+// what the generated code is made of
+type Spec struct {
+	Title      string
+	Operations []Operation
+	Types      []Type
+	// Source is the document as it was read, and Ext the
+	// extension of its file, .yaml, .yml or .json
+	Source []byte
+	Ext    string
+}
+
+// An Operation is a single method on a path of the document
+type Operation struct {
+	Handler     string // Name of the Go handler method
+	Method      string // Uppercase HTTP method
+	Path        string // Path with {param} placeholders
+	Summary     string
+	PathParams  []Param
+	QueryParams []Param
+	// RequestType and ResponseType are the Go types of the JSON
+	// request body and success response, if any, with generated
+	// types qualified by TypesPackage
+	RequestType  string
+	ResponseType string
+	// SampleBody is a JSON document the request body decodes
+	// from, sent by the generated tests
+	SampleBody string
+	Status     int // Status code of the success response
+}
+
+// A Param is a path or query parameter of an Operation
+type Param struct {
+	Name     string
+	GoName   string
+	GoType   string
+	Required bool
+}
+
+// A Type is a Go type generated from a schema of the document
+type Type struct {
+	Name        string
+	Description string
+	// Underlying is set for schemas that are not objects,
+	// which become defined types instead of structs
+	Underlying string
+	Fields     []Field
+}
+
+// A Field is a property of an object schema
+type Field struct {
+	Name     string
+	JSONName string
+	GoType   string
+	Required bool
+}
+
+// TypesPackage is the package generated types are written into,
+// internal/api in the generated project
+const TypesPackage = "api"
+
+// methods lists the operations of a path item in generation order
+var methods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// reservedHandlers are the methods generated servers
+// already have, which handlers must not collide with
+var reservedHandlers = []string{"RegisterRoutes", "RegisterFiberRoutes", "HelloWorldHandler"}
+
+// Load reads the OpenAPI document at name, in YAML or JSON
+func Load(name string) (*Spec, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext != ".json" && ext != ".yml" {
+		ext = ".yaml"
+	}
+
+	spec, err := Parse(content, ext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %s: %w", name, err)
+	}
+	return spec, nil
+}
+
+// Parse reads an OpenAPI document, YAML being a superset of JSON
+func Parse(content []byte, ext string) (*Spec, error) {
+	doc := &document{}
+	if err := yaml.NewDecoder(bytes.NewReader(content)).Decode(doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("only OpenAPI 3 documents are supported, got version %q", doc.OpenAPI)
+	}
+
+	g := &generator{doc: doc, types: make(map[string]bool)}
+	spec := &Spec{Title: doc.Info.Title, Source: content, Ext: ext}
+
+	// Component schemas come first, in declaration order,
+	// so that references to them resolve to their name
+	for _, name := range doc.Components.Schemas.keys {
+		g.types[goName(name)] = true
+	}
+	for _, name := range doc.Components.Schemas.keys {
+		g.defineType(goName(name), doc.Components.Schemas.values[name])
+	}
+
+	handlers := slices.Clone(reservedHandlers)
+	paths := slices.Clone(doc.Paths.keys)
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths.values[path]
+		for _, method := range methods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+
+			operation, err := g.operation(method, path, item.Parameters, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operation.Handler = unique(operation.Handler, handlers)
+			handlers = append(handlers, operation.Handler)
+			spec.Operations = append(spec.Operations, operation)
+		}
+	}
+
+	spec.Types = g.defined
+	return spec, nil
+}
+
+// UsesTime reports whether the generated types need the time package
+func (s *Spec) UsesTime() bool {
+	for _, t := range s.Types {
+		if strings.Contains(t.Underlying, "time.Time") {
+			return true
+		}
+		for _, field := range t.Fields {
+			if strings.Contains(field.GoType, "time.Time") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasPathParams reports whether an operation has path parameters
+func (s *Spec) HasPathParams() bool {
+	for _, operation := range s.Operations {
+		if len(operation.PathParams) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestsUse reports whether the request type of an
+// operation refers to the package with the given name
+func (s *Spec) RequestsUse(pkg string) bool {
+	for _, operation := range s.Operations {
+		if strings.Contains(operation.RequestType, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Locals returns the variables a stub handler reads from the
+// request, the decoded body followed by the parameters
+func (o Operation) Locals() []string {
+	var locals []string
+	if o.RequestType != "" {
+		locals = append(locals, "req")
+	}
+	for _, param := range append(slices.Clone(o.PathParams), o.QueryParams...) {
+		locals = append(locals, param.GoName)
+	}
+	return locals
+}
+
+// MethodTitle returns the method as used by router functions, Get for GET
+func (o Operation) MethodTitle() string {
+	return o.Method[:1] + strings.ToLower(o.Method[1:])
+}
+
+// ColonPath returns the path with :param placeholders
+func (o Operation) ColonPath() string {
+	path := o.Path
+	for _, param := range o.PathParams {
+		path = strings.ReplaceAll(path, "{"+param.Name+"}", ":"+param.Name)
+	}
+	return path
+}
+
+// SamplePath returns a path matching the operation, with sample
+// values for its path parameters and required query parameters
+func (o Operation) SamplePath() string {
+	sample := func(param Param) string {
+		switch param.GoType {
+		case "int", "int32", "int64", "float32", "float64":
+			return "1"
+		case "bool":
+			return "true"
+		default:
+			return "sample"
+		}
+	}
+
+	path := o.Path
+	for _, param := range o.PathParams {
+		path = strings.ReplaceAll(path, "{"+param.Name+"}", sample(param))
+	}
+	separator := "?"
+	for _, param := range o.QueryParams {
+		if param.Required {
+			path += separator + param.Name + "=" + sample(param)
+			separator = "&"
+		}
+	}
+	return path
+}
+
+// StatusText returns the name of the net/http constant
+// of the success status, StatusOK for 200
+func (o Operation) StatusText() string {
+	if name, ok := statusNames[o.Status]; ok {
+		return "http." + name
+	}
+	return strconv.Itoa(o.Status)
+}
+
+var statusNames = map[int]string{
+	200: "StatusOK",
+	201: "StatusCreated",
+	202: "StatusAccepted",
+	204: "StatusNoContent",
+}
+
+// unique returns name, suffixed with a number when it is already taken
+func unique(name string, taken []string) string {
+	candidate := name
+	for i := 2; slices.Contains(taken, candidate); i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	return candidate
+}
@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	spec, err := Load("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.Title != "Petstore" || spec.Ext != ".yaml" {
+		t.Errorf("unexpected title %q or extension %q", spec.Title, spec.Ext)
+	}
+
+	expected := []Operation{
+		{
+			Handler:      "ListPets",
+			Method:       "GET",
+			Path:         "/pets",
+			Summary:      "List all pets",
+			QueryParams:  []Param{{Name: "limit", GoName: "limit", GoType: "int32"}},
+			ResponseType: "[]api.Pet",
+			Status:       200,
+		},
+		{
+			Handler:      "CreatePet",
+			Method:       "POST",
+			Path:         "/pets",
+			Summary:      "Create a pet",
+			RequestType:  "api.CreatePetRequest",
+			ResponseType: "api.Pet",
+			SampleBody:   "{}",
+			Status:       201,
+		},
+		{
+			Handler:      "GetPetsByPetID",
+			Method:       "GET",
+			Path:         "/pets/{petId}",
+			Summary:      "Info for a specific pet",
+			PathParams:   []Param{{Name: "petId", GoName: "petID", GoType: "int64", Required: true}},
+			ResponseType: "api.Pet",
+			Status:       200,
+		},
+		{
+			Handler:    "DeletePet",
+			Method:     "DELETE",
+			Path:       "/pets/{petId}",
+			PathParams: []Param{{Name: "petId", GoName: "petID", GoType: "int64", Required: true}},
+			Status:     204,
+		},
+	}
+	if !reflect.DeepEqual(spec.Operations, expected) {
+		t.Errorf("unexpected operations\ngot:  %+v\nwant: %+v", spec.Operations, expected)
+	}
+
+	var names []string
+	for _, typ := range spec.Types {
+		names = append(names, typ.Name)
+	}
+	if want := []string{"Pet", "PetOwner", "Error", "Status", "CreatePetRequest"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected types %v, got %v", want, names)
+	}
+
+	pet := spec.Types[0]
+	if pet.Description != "A pet of the store" || len(pet.Fields) != 6 {
+		t.Fatalf("unexpected Pet type %+v", pet)
+	}
+	for _, field := range []Field{
+		{Name: "ID", JSONName: "id", GoType: "int64", Required: true},
+		{Name: "BornAt", JSONName: "born_at", GoType: "time.Time"},
+		{Name: "Owner", JSONName: "owner", GoType: "PetOwner"},
+		{Name: "Labels", JSONName: "labels", GoType: "map[string]string"},
+	} {
+		found := false
+		for _, got := range pet.Fields {
+			found = found || got == field
+		}
+		if !found {
+			t.Errorf("expected Pet to have the field %+v, got %+v", field, pet.Fields)
+		}
+	}
+	if spec.Types[3].Underlying != "string" {
+		t.Errorf("expected Status to be a string, got %q", spec.Types[3].Underlying)
+	}
+
+	if !spec.UsesTime() || !spec.HasPathParams() || !spec.RequestsUse(TypesPackage) || spec.RequestsUse("time") {
+		t.Error("unexpected packages used by the generated code")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"swagger 2":        "swagger: \"2.0\"\n",
+		"missing schema":   "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      responses:\n        \"200\":\n          content:\n            application/json:\n              schema:\n                $ref: \"#/components/schemas/Missing\"\n",
+		"remote reference": "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      parameters:\n        - $ref: \"other.yaml#/Param\"\n",
+	}
+	for name, content := range tests {
+		if _, err := Parse([]byte(content), ".yaml"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestOperationPaths(t *testing.T) {
+	operation := Operation{
+		Method:      "GET",
+		Path:        "/users/{userId}/posts",
+		PathParams:  []Param{{Name: "userId", GoType: "int64"}},
+		QueryParams: []Param{{Name: "q", GoType: "string", Required: true}, {Name: "page", GoType: "int"}},
+	}
+
+	if got := operation.MethodTitle(); got != "Get" {
+		t.Errorf("expected Get, got %s", got)
+	}
+	if got := operation.ColonPath(); got != "/users/:userId/posts" {
+		t.Errorf("unexpected colon path %s", got)
+	}
+	if got := operation.SamplePath(); got != "/users/1/posts?q=sample" {
+		t.Errorf("unexpected sample path %s", got)
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name, goName, goVar string
+	}{
+		{"pet_id", "PetID", "petID"},
+		{"listPets", "ListPets", "listPets"},
+		{"HTTPStatus", "HTTPStatus", "httpStatus"},
+		{"X-Request-Id", "XRequestID", "xRequestID"},
+		{"type", "Type", "typeParam"},
+		{"2fa", "N2fa", "n2fa"},
+	}
+	for _, tt := range tests {
+		if got := goName(tt.name); got != tt.goName {
+			t.Errorf("goName(%q) = %q, want %q", tt.name, got, tt.goName)
+		}
+		if got := goVar(tt.name); got != tt.goVar {
+			t.Errorf("goVar(%q) = %q, want %q", tt.name, got, tt.goVar)
+		}
+	}
+
+	if got := handlerName("get", "/users/{id}/posts"); got != "GetUsersByIDPosts" {
+		t.Errorf("unexpected handler name %s", got)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+	"github.com/spf13/cobra"
+)
+
+// addTemplateDirFlag registers the flag giving the
+// directory of template overlays to the command
+func addTemplateDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template-dir", "", "Directory of templates overriding the embedded ones with the same relative path, such as framework/files/routes/chi.go.tmpl. Defaults to ~/.config/go-blueprint/templates when it exists")
+}
+
+// loadTemplateOverlays loads the templates of the --template-dir
+// flag, or of the default overlay directory when it exists
+func loadTemplateOverlays(cmd *cobra.Command) error {
+	dir := cmd.Flag("template-dir").Value.String()
+	if dir == "" {
+		dir = template.DefaultOverlayDir()
+		if _, err := os.Stat(dir); dir == "" || errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+	}
+
+	if err := template.LoadOverlays(dir); err != nil {
+		return fmt.Errorf("could not load the templates of %s: %w", dir, err)
+	}
+	fmt.Println(tipMsgStyle.Render(fmt.Sprintf("Using the templates of %s", dir)))
+
+	return nil
+}
+
+// printUnusedOverlays warns about the loaded templates that the
+// project did not use, usually because of a misspelled path
+func printUnusedOverlays() {
+	unused := template.UnusedOverlays()
+	if len(unused) == 0 {
+		return
+	}
+
+	fmt.Println(tipMsgStyle.Render("\nThe following templates were not used by this project, check their path matches an embedded template:"))
+	for _, name := range unused {
+		fmt.Println(tipMsgStyle.Render(fmt.Sprintf("• %s", name)))
+	}
+}
@@ -0,0 +1,421 @@
+package program
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/openapi"
+	"github.com/melkeydev/go-blueprint/cmd/utils"
+)
+
+// ErrNotBlueprintProject is returned when a directory does not hold
+// a project generated by Blueprint
+var ErrNotBlueprintProject = errors.New("not a project generated by go-blueprint")
+
+// A PlannedFile is a file that adding features to
+// an existing project creates or overwrites
+type PlannedFile struct {
+	Path    string // Path relative to the project directory
+	Content []byte
+	Exists  bool
+}
+
+// writeProjectFile writes content to rel, a path relative to the
+// project directory at projectPath in fsys, creating its directory
+func writeProjectFile(fsys filesystem.FileSystem, projectPath, rel string, content []byte) error {
+	name := filepath.Join(projectPath, filepath.FromSlash(rel))
+	if err := fsys.MkdirAll(filepath.Dir(name), 0o751); err != nil {
+		return err
+	}
+	return fsys.WriteFile(name, content, 0o644)
+}
+
+// A FeaturePlan contains the changes needed to add advanced
+// features to an existing project
+type FeaturePlan struct {
+	ProjectPath string
+	// FS is the file system holding the project. The dependencies
+	// are only resolved on the operating system
+	FS    filesystem.FileSystem
+	Files []PlannedFile
+	// Modified lists the files the features need to rewrite
+	// that were changed since the project was generated
+	Modified []string
+
+	// manifest is updated with the added features and files
+	manifest *Manifest
+}
+
+// DetectProject reads the project generated by Blueprint at projectPath
+// and returns a Project holding the options it was generated with. They
+// come from the project manifest, or are guessed from go.mod and the
+// project files for projects generated before manifests existed
+func DetectProject(projectPath string) (*Project, error) {
+	for _, required := range []string{filepath.Join(cmdApiPath, "main.go"), filepath.Join(internalServerPath, "server.go")} {
+		if _, err := os.Stat(filepath.Join(projectPath, required)); err != nil {
+			return nil, fmt.Errorf("%w: %s is missing", ErrNotBlueprintProject, required)
+		}
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotBlueprintProject, err)
+	}
+	moduleName, requires := parseGoMod(goMod)
+	if moduleName == "" {
+		return nil, fmt.Errorf("%w: go.mod has no module directive", ErrNotBlueprintProject)
+	}
+
+	p := &Project{
+		ProjectName:     moduleName,
+		ProjectType:     flags.StandardLibrary,
+		DBDriver:        flags.None,
+		FrameworkMap:    make(map[flags.Framework]Framework),
+		DBDriverMap:     make(map[flags.Database]Driver),
+		AdvancedOptions: make(map[string]bool),
+		GitOptions:      flags.Skip,
+		FS:              filesystem.OS{},
+	}
+	p.createFrameworkMap()
+	p.createDBDriverMap()
+
+	manifest, err := ReadManifest(filesystem.OS{}, projectPath)
+	if err == nil {
+		p.manifest = manifest
+		p.ProjectType = manifest.Framework
+		p.DBDriver = manifest.Driver
+		p.ORM = manifest.ORM
+		p.GitOptions = manifest.Git
+		for _, feature := range manifest.Features {
+			p.AdvancedOptions[feature] = true
+		}
+		if manifest.OpenAPI != "" {
+			p.OpenAPI, err = openapi.Load(filepath.Join(projectPath, filepath.FromSlash(manifest.OpenAPI)))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return p, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, name := range flags.AllowedProjectTypes {
+		framework, ok := p.FrameworkMap[flags.Framework(name)]
+		if ok && len(framework.packageName) > 0 && requiresPackage(requires, framework.packageName[0]) {
+			p.ProjectType = flags.Framework(name)
+			break
+		}
+	}
+	for _, name := range flags.AllowedDBDrivers {
+		driver, ok := p.DBDriverMap[flags.Database(name)]
+		if ok && len(driver.packageName) > 0 && requiresPackage(requires, driver.packageName[0]) {
+			p.DBDriver = flags.Database(name)
+			break
+		}
+	}
+	for _, o := range orms {
+		if packages, ok := o.Packages[p.DBDriver]; ok && requiresPackage(requires, packages[0]) {
+			p.ORM = o.Name
+			break
+		}
+	}
+
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(projectPath, name))
+		return err == nil
+	}
+	p.AdvancedOptions[flags.Docker] = exists("Dockerfile")
+	p.AdvancedOptions[flags.GoProjectWorkflow] = exists(filepath.Join(gitHubActionPath, "go-test.yml"))
+	p.AdvancedOptions[flags.Htmx] = exists(filepath.Join(cmdWebPath, "hello.templ"))
+	p.AdvancedOptions[flags.Tailwind] = exists(filepath.Join(cmdWebPath, "styles", "input.css"))
+	p.AdvancedOptions[flags.React] = exists("frontend")
+	p.AdvancedOptions[flags.Migrations] = exists(filepath.Join(migrationsPath, "migrations.go"))
+	p.AdvancedOptions[flags.Sqlc] = exists("sqlc.yaml")
+	p.AdvancedOptions[flags.Grpc] = exists("buf.yaml")
+	p.AdvancedOptions[flags.Graphql] = exists("gqlgen.yml")
+	p.AdvancedOptions[flags.Auth] = exists(filepath.Join(internalAuthPath, "auth.go"))
+	p.AdvancedOptions[flags.Otel] = exists(filepath.Join(internalTelemetryPath, "telemetry.go"))
+	p.AdvancedOptions[flags.Metrics] = exists(filepath.Join(internalMetricsPath, "metrics.go"))
+	p.AdvancedOptions[flags.Kubernetes] = exists(filepath.Join(deployKubernetesPath, "deployment.yaml"))
+	p.AdvancedOptions[flags.Helm] = exists(filepath.Join(deployHelmPath, "Chart.yaml"))
+	p.AdvancedOptions[flags.Websocket] = requiresPackage(requires, "github.com/coder/websocket") ||
+		requiresPackage(requires, "github.com/gofiber/contrib/websocket")
+
+	if p.AdvancedOptions[flags.React] {
+		viteConfig, err := os.ReadFile(filepath.Join(projectPath, "frontend", "vite.config.ts"))
+		p.AdvancedOptions[flags.Tailwind] = err == nil && bytes.Contains(viteConfig, []byte("@tailwindcss/vite"))
+	}
+
+	return p, nil
+}
+
+// PlanFeatures compares the project as it was generated with the same
+// project generated with the given features, and returns the files
+// that adding them creates or overwrites. Both versions are rendered
+// in memory, so the files of the project are left untouched
+func (p *Project) PlanFeatures(projectPath string, features []string) (*FeaturePlan, error) {
+	for _, feature := range features {
+		if feature == flags.React {
+			return nil, fmt.Errorf("react can only be selected when creating a project, as its frontend is scaffolded by 'npm create vite'")
+		}
+		if p.AdvancedOptions[flags.React] && (feature == flags.Htmx || feature == flags.Tailwind) {
+			return nil, fmt.Errorf("%s cannot be added to a project using react", feature)
+		}
+	}
+
+	base, baseRoot, err := p.renderInMemory(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not render the project as generated: %w", err)
+	}
+	target, targetRoot, err := p.renderInMemory(features)
+	if err != nil {
+		return nil, fmt.Errorf("could not render the project with %s: %w", strings.Join(features, ", "), err)
+	}
+
+	manifest := p.manifest
+	if manifest == nil {
+		manifest, err = p.reconstructManifest(projectPath, base, baseRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A file is unmodified when it matches the checksum it was
+	// generated with, or the version rendered in memory for
+	// files the manifest does not know about
+	unmodified := func(rel string, current []byte) bool {
+		if _, ok := manifest.Files[rel]; ok {
+			return manifest.Unmodified(rel, current)
+		}
+		generated, err := base.ReadFile(path.Join(baseRoot, rel))
+		return err == nil && sameContent(rel, current, generated)
+	}
+
+	plan := &FeaturePlan{ProjectPath: projectPath, FS: p.FS, manifest: manifest}
+	for _, feature := range features {
+		if !slices.Contains(manifest.Features, feature) {
+			manifest.Features = append(manifest.Features, feature)
+		}
+	}
+	slices.Sort(manifest.Features)
+
+	for _, name := range target.Paths() {
+		rel := strings.TrimPrefix(name, targetRoot+"/")
+		// go.mod is maintained by the go command, "go mod tidy"
+		// picks up the dependencies of the new files
+		if rel == "go.mod" || rel == ManifestFile {
+			continue
+		}
+
+		content, err := target.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		generated, baseErr := base.ReadFile(path.Join(baseRoot, rel))
+		if baseErr == nil && sameContent(rel, generated, content) {
+			continue
+		}
+
+		current, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, os.ErrNotExist) && baseErr != nil:
+			plan.Files = append(plan.Files, PlannedFile{Path: rel, Content: content})
+		case err != nil:
+			// The file was generated and then removed
+			plan.Modified = append(plan.Modified, rel)
+		case sameContent(rel, current, content):
+			// Already there, nothing to do
+		case unmodified(rel, current):
+			plan.Files = append(plan.Files, PlannedFile{Path: rel, Content: content, Exists: true})
+		default:
+			plan.Modified = append(plan.Modified, rel)
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply writes the planned files into the project directory of FS
+// and, on the operating system, resolves the dependencies they need.
+// It refuses to run when the plan would overwrite files modified since
+// the generation
+func (plan *FeaturePlan) Apply() error {
+	if len(plan.Modified) > 0 {
+		return fmt.Errorf("refusing to overwrite files modified since the project was generated: %s", strings.Join(plan.Modified, ", "))
+	}
+
+	for _, file := range plan.Files {
+		if err := writeProjectFile(plan.FS, plan.ProjectPath, file.Path, file.Content); err != nil {
+			return err
+		}
+		plan.manifest.Files[file.Path] = Checksum(file.Content)
+	}
+
+	if len(plan.Files) == 0 {
+		return nil
+	}
+
+	content, err := plan.manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := writeProjectFile(plan.FS, plan.ProjectPath, ManifestFile, content); err != nil {
+		return err
+	}
+	if !onDisk(plan.FS) {
+		return nil
+	}
+
+	// The new files may import the code their packages generate
+	for _, dir := range plan.GeneratedPackages() {
+		if err := utils.ExecuteCmd("go", []string{"generate", "./" + dir}, plan.ProjectPath); err != nil {
+			return err
+		}
+	}
+
+	return utils.GoTidy(plan.ProjectPath)
+}
+
+// GeneratedPackages returns the directories of the planned Go files
+// declaring go:generate directives
+func (plan *FeaturePlan) GeneratedPackages() []string {
+	var dirs []string
+	for _, file := range plan.Files {
+		if path.Ext(file.Path) != ".go" || !bytes.Contains(file.Content, []byte("\n//go:generate ")) {
+			continue
+		}
+		if dir := path.Dir(file.Path); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	slices.Sort(dirs)
+	return dirs
+}
+
+// reconstructManifest builds the manifest of a project generated
+// before manifests existed. Files still matching the project rendered
+// in memory are recorded as generated
+func (p *Project) reconstructManifest(projectPath string, base *filesystem.Memory, baseRoot string) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:   p.BlueprintVersion,
+		Name:      p.ProjectName,
+		Framework: p.ProjectType,
+		Driver:    p.DBDriver,
+		ORM:       p.ORM,
+		Features:  p.enabledFeatures(),
+		Git:       p.GitOptions,
+		Files:     make(map[string]string),
+	}
+
+	for _, name := range base.Paths() {
+		rel := strings.TrimPrefix(name, baseRoot+"/")
+		if rel == "go.mod" || rel == ManifestFile {
+			continue
+		}
+
+		generated, err := base.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		current, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		if err == nil && sameContent(rel, current, generated) {
+			manifest.Files[rel] = Checksum(current)
+		}
+	}
+
+	return manifest, nil
+}
+
+// renderInMemory generates a copy of the Project, with the extra
+// advanced features enabled, into memory. It returns the file system
+// and the slash separated directory holding the project
+func (p *Project) renderInMemory(features []string) (*filesystem.Memory, string, error) {
+	memory := filesystem.NewMemory()
+	project := &Project{
+		ProjectName:     p.ProjectName,
+		AbsolutePath:    "/",
+		ProjectType:     p.ProjectType,
+		DBDriver:        p.DBDriver,
+		ORM:             p.ORM,
+		FrameworkMap:    make(map[flags.Framework]Framework),
+		DBDriverMap:     make(map[flags.Database]Driver),
+		AdvancedOptions: maps.Clone(p.AdvancedOptions),
+		GitOptions:      flags.Skip,
+		OpenAPI:         p.OpenAPI,
+		FS:              memory,
+	}
+	for _, feature := range features {
+		project.AdvancedOptions[feature] = true
+	}
+
+	if err := project.CreateMainFile(); err != nil {
+		return nil, "", err
+	}
+
+	return memory, path.Join("/", utils.GetRootDir(project.ProjectName)), nil
+}
+
+// sameContent compares two versions of the named file. Go files are
+// compared once formatted, as generation formats them with gofmt
+func sameContent(name string, a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	if path.Ext(name) != ".go" {
+		return false
+	}
+
+	formattedA, errA := format.Source(a)
+	formattedB, errB := format.Source(b)
+	return errA == nil && errB == nil && bytes.Equal(formattedA, formattedB)
+}
+
+// parseGoMod returns the module path declared in a go.mod file,
+// along with the modules it requires directly
+func parseGoMod(goMod []byte) (string, []string) {
+	var moduleName string
+	var requires []string
+
+	inRequireBlock := false
+	for _, line := range strings.Split(string(goMod), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "module "):
+			moduleName = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		case line == "require (":
+			inRequireBlock = true
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+		case inRequireBlock, strings.HasPrefix(line, "require "):
+			if strings.HasSuffix(line, "// indirect") {
+				continue
+			}
+			fields := strings.Fields(strings.TrimPrefix(line, "require "))
+			if len(fields) > 0 {
+				requires = append(requires, fields[0])
+			}
+		}
+	}
+
+	return moduleName, requires
+}
+
+// requiresPackage reports whether one of the required
+// modules provides the given package
+func requiresPackage(requires []string, packageName string) bool {
+	return slices.ContainsFunc(requires, func(module string) bool {
+		return packageName == module || strings.HasPrefix(packageName, module+"/")
+	})
+}
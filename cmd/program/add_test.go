@@ -0,0 +1,236 @@
+package program
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+func TestParseGoMod(t *testing.T) {
+	goMod := `module github.com/user/blueprint
+
+go 1.23.0
+
+require github.com/joho/godotenv v1.5.1
+
+require (
+	github.com/go-chi/chi/v5 v5.1.0
+	github.com/jackc/pgx/v5 v5.7.1
+	golang.org/x/text v0.18.0 // indirect
+)
+`
+	moduleName, requires := parseGoMod([]byte(goMod))
+	if moduleName != "github.com/user/blueprint" {
+		t.Errorf("expected module github.com/user/blueprint, got %s", moduleName)
+	}
+	expected := []string{"github.com/joho/godotenv", "github.com/go-chi/chi/v5", "github.com/jackc/pgx/v5"}
+	if !reflect.DeepEqual(requires, expected) {
+		t.Errorf("expected requires %v, got %v", expected, requires)
+	}
+
+	if !requiresPackage(requires, "github.com/jackc/pgx/v5/stdlib") {
+		t.Errorf("expected github.com/jackc/pgx/v5 to provide its stdlib package")
+	}
+	if requiresPackage(requires, "github.com/go-chi/chi") {
+		t.Errorf("did not expect github.com/go-chi/chi/v5 to provide github.com/go-chi/chi")
+	}
+}
+
+// writeProject generates a project into memory and writes
+// it into a temporary directory, returning its path
+func writeProject(t *testing.T, framework flags.Framework, driver flags.Database, features ...string) string {
+	t.Helper()
+
+	project, _ := newTestProject(framework, driver, features...)
+	project.createFrameworkMap()
+	project.createDBDriverMap()
+	memory, root, err := project.renderInMemory(nil)
+	if err != nil {
+		t.Fatalf("could not render project: %v", err)
+	}
+
+	projectPath := t.TempDir()
+	for _, name := range memory.Paths() {
+		content, err := memory.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target := filepath.Join(projectPath, filepath.FromSlash(strings.TrimPrefix(name, root+"/")))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// go.mod is written offline without requirements, add
+	// the ones "go get" would have added
+	goMod := "module github.com/user/blueprint\n\ngo 1.23.0\n\nrequire (\n"
+	for _, packages := range [][]string{project.FrameworkMap[framework].packageName, project.DBDriverMap[driver].packageName} {
+		for _, packageName := range packages {
+			goMod += "\t" + packageName + " v1.0.0\n"
+		}
+	}
+	goMod += ")\n"
+	if err := os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return projectPath
+}
+
+func TestDetectProject(t *testing.T) {
+	projectPath := writeProject(t, flags.Gin, flags.Postgres, flags.Docker)
+
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatalf("could not detect project: %v", err)
+	}
+	if project.ProjectName != "github.com/user/blueprint" || project.ProjectType != flags.Gin || project.DBDriver != flags.Postgres {
+		t.Errorf("unexpected project %s using %s with %s", project.ProjectName, project.ProjectType, project.DBDriver)
+	}
+	if !project.AdvancedOptions[flags.Docker] || project.AdvancedOptions[flags.Htmx] {
+		t.Errorf("unexpected advanced options %v", project.AdvancedOptions)
+	}
+
+	if _, err := DetectProject(t.TempDir()); err == nil {
+		t.Errorf("expected an empty directory not to be detected as a project")
+	}
+}
+
+func TestPlanFeatures(t *testing.T) {
+	projectPath := writeProject(t, flags.Chi, flags.Postgres)
+
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatalf("could not detect project: %v", err)
+	}
+
+	plan, err := project.PlanFeatures(projectPath, []string{flags.Docker})
+	if err != nil {
+		t.Fatalf("could not plan features: %v", err)
+	}
+	if len(plan.Modified) != 0 {
+		t.Errorf("expected no modified files, got %v", plan.Modified)
+	}
+	planned := make(map[string]bool)
+	for _, file := range plan.Files {
+		planned[file.Path] = file.Exists
+	}
+	if exists, ok := planned["Dockerfile"]; !ok || exists {
+		t.Errorf("expected Dockerfile to be created, got %v", plan.Files)
+	}
+	if exists, ok := planned["docker-compose.yml"]; !ok || !exists {
+		t.Errorf("expected docker-compose.yml to be updated, got %v", plan.Files)
+	}
+
+	// A modified file the feature needs is never overwritten
+	envPath := filepath.Join(projectPath, ".env")
+	if err := os.WriteFile(envPath, []byte("PORT=9090\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plan, err = project.PlanFeatures(projectPath, []string{flags.Docker})
+	if err != nil {
+		t.Fatalf("could not plan features: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Modified, []string{".env"}) {
+		t.Errorf("expected .env to be reported as modified, got %v", plan.Modified)
+	}
+	if err := plan.Apply(); err == nil {
+		t.Errorf("expected applying a plan with modified files to fail")
+	}
+}
+
+func TestFeaturePlanApply(t *testing.T) {
+	memory := filesystem.NewMemory()
+	plan := &FeaturePlan{
+		ProjectPath: "/workspace/blueprint",
+		FS:          memory,
+		Files:       []PlannedFile{{Path: "internal/server/websocket.go", Content: []byte("package server\n")}},
+		manifest:    &Manifest{Features: []string{flags.Websocket}, Files: map[string]string{}},
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("could not apply plan: %v", err)
+	}
+
+	content, err := memory.ReadFile("/workspace/blueprint/internal/server/websocket.go")
+	if err != nil || string(content) != "package server\n" {
+		t.Errorf("expected the planned file to be written, got %q: %v", content, err)
+	}
+	manifest, err := ReadManifest(memory, "/workspace/blueprint")
+	if err != nil {
+		t.Fatalf("could not read manifest: %v", err)
+	}
+	if !manifest.Unmodified("internal/server/websocket.go", content) {
+		t.Errorf("expected the manifest to hold the checksum of the written file, got %v", manifest.Files)
+	}
+}
+
+func TestPlanFeaturesWithoutManifest(t *testing.T) {
+	projectPath := writeProject(t, flags.Echo, flags.None, flags.Docker)
+	if err := os.Remove(filepath.Join(projectPath, ManifestFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatalf("could not detect project: %v", err)
+	}
+	if project.ProjectType != flags.Echo || !project.AdvancedOptions[flags.Docker] {
+		t.Errorf("unexpected project using %s with %v", project.ProjectType, project.AdvancedOptions)
+	}
+
+	plan, err := project.PlanFeatures(projectPath, []string{flags.GoProjectWorkflow})
+	if err != nil {
+		t.Fatalf("could not plan features: %v", err)
+	}
+	if len(plan.Modified) != 0 || len(plan.Files) != 3 {
+		t.Errorf("expected the 3 workflow files to be created, got %d files and modified %v", len(plan.Files), plan.Modified)
+	}
+	if !plan.manifest.Unmodified("cmd/api/main.go", mustReadFile(t, filepath.Join(projectPath, "cmd/api/main.go"))) {
+		t.Errorf("expected the reconstructed manifest to record cmd/api/main.go")
+	}
+	if !reflect.DeepEqual(plan.manifest.Features, []string{flags.Docker, flags.GoProjectWorkflow}) {
+		t.Errorf("unexpected manifest features %v", plan.manifest.Features)
+	}
+}
+
+func TestPlanFeaturesGenerated(t *testing.T) {
+	projectPath := writeProject(t, flags.Gin, flags.Sqlite)
+
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatalf("could not detect project: %v", err)
+	}
+
+	plan, err := project.PlanFeatures(projectPath, []string{flags.Graphql})
+	if err != nil {
+		t.Fatalf("could not plan features: %v", err)
+	}
+	planned := make(map[string]bool)
+	for _, file := range plan.Files {
+		planned[file.Path] = file.Exists
+	}
+	if exists, ok := planned["internal/server/routes.go"]; !ok || !exists {
+		t.Errorf("expected routes.go to be updated, got %v", plan.Files)
+	}
+	if !reflect.DeepEqual(plan.GeneratedPackages(), []string{"internal/graph"}) {
+		t.Errorf("expected internal/graph to be generated, got %v", plan.GeneratedPackages())
+	}
+}
+
+func mustReadFile(t *testing.T, name string) []byte {
+	t.Helper()
+
+	content, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
@@ -0,0 +1,113 @@
+package program
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
+	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+)
+
+// authData is the data the templates of the auth feature are rendered
+// with: the Store holding the users, without a driver when they are
+// kept in memory
+type authData struct {
+	Store
+}
+
+// userStore returns the store of the users of the auth feature,
+// the first SQL one of the project. Without one, the users
+// are kept in memory
+func (p *Project) userStore() authData {
+	if stores := p.MigratedStores(); len(stores) > 0 {
+		return authData{Store: stores[0]}
+	}
+	return authData{Store: Store{Project: p, DBDriver: flags.None}}
+}
+
+// Migrated reports whether the users table is created by
+// a migration, rather than by the store of the users
+func (d authData) Migrated() bool {
+	return d.AdvancedOptions[flags.Migrations] && d.DBDriver != flags.None
+}
+
+// NewUserStore returns the expression of a Server method
+// creating the store of the users
+func (d authData) NewUserStore() string {
+	switch {
+	case d.DBDriver == flags.None:
+		return "auth.NewMemoryStore()"
+	case d.MultipleDrivers():
+		return d.DatabasePackage() + ".NewUserStore(s.db." + d.GoName() + "())"
+	default:
+		return d.DatabasePackage() + ".NewUserStore(s.db)"
+	}
+}
+
+// CreateAuthFiles creates the auth package, the store of the users
+// and the handlers of the routes registering and logging them in
+func (p *Project) CreateAuthFiles(projectPath string) error {
+	templater, ok := p.FrameworkMap[p.ProjectType].templater.(AuthTemplater)
+	if !ok {
+		return fmt.Errorf("framework '%s' does not support the %s feature", p.ProjectType, flags.Auth)
+	}
+
+	err := p.goGetPackage(projectPath, authPackages)
+	if err != nil {
+		return err
+	}
+
+	err = p.CreatePath(internalAuthPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalAuthPath)
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, internalAuthPath, "auth.go"), advanced.Auth()},
+		{filepath.Join(projectPath, internalAuthPath, "token.go"), advanced.AuthToken()},
+		{filepath.Join(projectPath, internalAuthPath, "password.go"), advanced.AuthPassword()},
+		{filepath.Join(projectPath, internalAuthPath, "memory.go"), advanced.AuthMemory()},
+		{filepath.Join(projectPath, internalAuthPath, "auth_test.go"), advanced.AuthTest()},
+	}
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := p.userStore()
+	if data.DBDriver != flags.None {
+		err = p.render(filepath.Join(projectPath, data.Path(), "users.go"), advanced.Users(), data, advanced.UsersBlocks())
+		if err != nil {
+			return err
+		}
+	}
+	if data.Migrated() {
+		dir := filepath.Join(projectPath, migrationsPath, data.Dir())
+		err = p.render(filepath.Join(dir, "000002_create_users.up.sql"), advanced.UsersUpMigration(), data, advanced.UsersBlocks())
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(dir, "000002_create_users.down.sql"), advanced.UsersDownMigration(), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.render(filepath.Join(projectPath, internalServerPath, "auth.go"), advanced.AuthServer(), data)
+	if err != nil {
+		return err
+	}
+	err = p.render(filepath.Join(projectPath, internalServerPath, "auth_handlers.go"), templater.AuthHandlers(), data, framework.AuthHTTPHandlersTemplate())
+	if err != nil {
+		return err
+	}
+	return p.render(filepath.Join(projectPath, internalServerPath, "auth_handlers_test.go"), templater.AuthTests(), data, framework.AuthTestCasesTemplate())
+}
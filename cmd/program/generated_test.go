@@ -0,0 +1,89 @@
+//go:build integration
+
+package program
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+// TestGeneratedProjectsVet generates a project with a resource for every
+// framework, writes it to disk and vets it. Resolving the dependencies of
+// the projects needs the network, the test only runs with the integration
+// build tag
+func TestGeneratedProjectsVet(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("skipping the generated projects without go")
+	}
+
+	tests := []struct {
+		framework flags.Framework
+		driver    flags.Database
+		features  []string
+	}{
+		{flags.Chi, flags.Postgres, []string{flags.Auth}},
+		{flags.Gin, flags.MySql, nil},
+		{flags.Fiber, "postgres,redis", []string{flags.Auth}},
+		{flags.GorillaMux, flags.None, nil},
+		{flags.HttpRouter, flags.Sqlite, []string{flags.Migrations}},
+		{flags.StandardLibrary, flags.Scylla, nil},
+		{flags.Echo, flags.Postgres, []string{flags.Migrations, flags.Auth}},
+	}
+
+	resource, err := ParseResource("post", "title:string,views:int,published_at:time")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			t.Parallel()
+
+			// The project is generated offline, go mod tidy resolves its
+			// requirements the way go get does for the create command
+			project, memory := newTestProject(tt.framework, tt.driver, tt.features...)
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+			projectPath := t.TempDir()
+			for _, name := range memory.Paths() {
+				content, err := memory.ReadFile(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				rel, err := filepath.Rel("/workspace/blueprint", name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := writeProjectFile(filesystem.OS{}, projectPath, filepath.ToSlash(rel), content); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			detected, err := DetectProject(projectPath)
+			if err != nil {
+				t.Fatalf("could not detect project: %v", err)
+			}
+			plan, err := detected.PlanResource(projectPath, resource, "")
+			if err != nil {
+				t.Fatalf("could not plan resource: %v", err)
+			}
+			if err := plan.Apply(); err != nil {
+				t.Fatalf("could not apply resource: %v", err)
+			}
+
+			for _, args := range [][]string{{"mod", "tidy"}, {"vet", "./..."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = projectPath
+				if output, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("go %s failed: %v\n%s", strings.Join(args, " "), err, output)
+				}
+			}
+		})
+	}
+}
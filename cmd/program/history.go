@@ -0,0 +1,159 @@
+package program
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// BaseRef is the git reference recording the files as Blueprint last
+// generated them, which upgrades use as the base of their merges
+const BaseRef = "refs/blueprint/base"
+
+// history looks up earlier versions of the
+// project files in its git repository
+type history struct {
+	dir string
+}
+
+// openHistory returns the history of the project at projectPath,
+// or nil when it is not part of a git repository
+func openHistory(projectPath string) *history {
+	h := &history{dir: projectPath}
+	if _, err := h.git(nil, nil, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil
+	}
+	return h
+}
+
+// HasUncommittedChanges reports whether the project at projectPath
+// is part of a git repository holding uncommitted changes to it
+func HasUncommittedChanges(projectPath string) bool {
+	h := openHistory(projectPath)
+	if h == nil {
+		return false
+	}
+	status, err := h.git(nil, nil, "status", "--porcelain", "--", ".")
+	return err != nil || len(bytes.TrimSpace(status)) > 0
+}
+
+// base returns the version of the file at the slash separated path
+// relative to the project that Blueprint generated. With a checksum,
+// it is the first version matching it, looked up in BaseRef and then
+// in every commit touching the file. Without one, it is the version
+// the file was first committed with
+func (h *history) base(rel, checksum string) ([]byte, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	log, err := h.git(nil, nil, "log", "--format=%H", "--", rel)
+	if err != nil {
+		return nil, false
+	}
+	revisions := strings.Fields(string(log))
+
+	if checksum == "" {
+		if len(revisions) == 0 {
+			return nil, false
+		}
+		content, err := h.show(revisions[len(revisions)-1], rel)
+		return content, err == nil
+	}
+
+	if _, err := h.git(nil, nil, "rev-parse", "--verify", "--quiet", BaseRef); err == nil {
+		revisions = slices.Insert(revisions, 0, BaseRef)
+	}
+	for _, revision := range revisions {
+		content, err := h.show(revision, rel)
+		if err == nil && Checksum(content) == checksum {
+			return content, true
+		}
+	}
+
+	return nil, false
+}
+
+// storeBase records the generated files in a commit referenced by
+// BaseRef, without touching the index or the working tree
+func (h *history) storeBase(files map[string][]byte, message string) error {
+	prefix, err := h.git(nil, nil, "rev-parse", "--show-prefix")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "go-blueprint-index")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmp, "index")}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var indexInfo bytes.Buffer
+	for _, name := range names {
+		blob, err := h.git(nil, files[name], "hash-object", "-w", "--stdin")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&indexInfo, "100644 %s\t%s%s\n", bytes.TrimSpace(blob), bytes.TrimSpace(prefix), name)
+	}
+	if _, err := h.git(env, indexInfo.Bytes(), "update-index", "--add", "--index-info"); err != nil {
+		return err
+	}
+	tree, err := h.git(env, nil, "write-tree")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit-tree", string(bytes.TrimSpace(tree)), "-m", message}
+	if parent, err := h.git(nil, nil, "rev-parse", "--verify", "--quiet", BaseRef); err == nil {
+		args = append(args, "-p", string(bytes.TrimSpace(parent)))
+	}
+	// The commit is only referenced by BaseRef, it does not
+	// need the identity of whoever runs the upgrade
+	identity := []string{
+		"GIT_AUTHOR_NAME=go-blueprint", "GIT_AUTHOR_EMAIL=go-blueprint@localhost",
+		"GIT_COMMITTER_NAME=go-blueprint", "GIT_COMMITTER_EMAIL=go-blueprint@localhost",
+	}
+	commit, err := h.git(identity, nil, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.git(nil, nil, "update-ref", BaseRef, string(bytes.TrimSpace(commit)))
+	return err
+}
+
+// show returns the content of the file at the slash separated
+// path relative to the project in the given revision
+func (h *history) show(revision, rel string) ([]byte, error) {
+	return h.git(nil, nil, "show", revision+":./"+rel)
+}
+
+func (h *history) git(env []string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = h.dir
+	cmd.Env = append(os.Environ(), env...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
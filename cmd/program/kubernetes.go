@@ -0,0 +1,205 @@
+package program
+
+import (
+	"bufio"
+	"bytes"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
+)
+
+// secretPlaceholder is the value of the secrets in the generated
+// manifests, to be replaced before deploying
+const secretPlaceholder = "change-me"
+
+// EnvVariable is a variable of the .env file of the project
+type EnvVariable struct {
+	Key   string
+	Value string
+}
+
+// Quoted returns the value as a double-quoted YAML string, so that
+// values like 8080 or true stay strings in a ConfigMap
+func (v EnvVariable) Quoted() string {
+	return strconv.Quote(v.Value)
+}
+
+// deployData is the data the Kubernetes manifests and the Helm chart
+// are rendered with: the variables of the .env file of the project,
+// split between the ConfigMap and the Secret
+type deployData struct {
+	*Project
+	Config  []EnvVariable
+	Secrets []EnvVariable
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Name returns the name of the Kubernetes resources, the name of the
+// service turned into a DNS label
+func (d deployData) Name() string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(d.ServiceName()), "-")
+	if len(name) > 50 {
+		// Leave room for the suffixes of the ConfigMap and Secret
+		name = name[:50]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// Port returns the port set by the key variable of the .env file
+func (d deployData) Port(key string) string {
+	for _, v := range d.Config {
+		if v.Key == key {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// isSecret reports whether the variable key holds a credential, kept
+// out of the ConfigMap
+func isSecret(key string) bool {
+	return strings.HasSuffix(key, "_PASSWORD") || strings.HasSuffix(key, "_SECRET")
+}
+
+// deployment returns the data of the manifests, with the variables of
+// the .env file set for a cluster: APP_ENV is production, the readiness
+// probe fails for a few seconds before the shutdown, and the secrets
+// are placeholders rather than the credentials of the local databases
+func (p *Project) deployment() (deployData, error) {
+	env, err := p.envFile()
+	if err != nil {
+		return deployData{}, err
+	}
+
+	data := deployData{Project: p}
+	for _, v := range parseEnv(env) {
+		switch {
+		case isSecret(v.Key):
+			if v.Value != "" {
+				v.Value = secretPlaceholder
+			}
+			data.Secrets = append(data.Secrets, v)
+		case v.Key == "APP_ENV":
+			data.Config = append(data.Config, EnvVariable{Key: v.Key, Value: "production"})
+		default:
+			data.Config = append(data.Config, v)
+		}
+	}
+	data.Config = append(data.Config, EnvVariable{Key: "SHUTDOWN_DELAY", Value: "5s"})
+	return data, nil
+}
+
+// parseEnv returns the variables of a .env file, skipping the comments
+// and removing the quotes around the values
+func parseEnv(env []byte) []EnvVariable {
+	var variables []EnvVariable
+	scanner := bufio.NewScanner(bytes.NewReader(env))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `'`)
+		}
+		variables = append(variables, EnvVariable{Key: strings.TrimSpace(key), Value: value})
+	}
+	return variables
+}
+
+// CreateKubernetesFiles writes the manifests deploying the image of
+// the application to Kubernetes, applied with kustomize
+func (p *Project) CreateKubernetesFiles(projectPath string) error {
+	err := p.CreatePath(deployKubernetesPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", deployKubernetesPath)
+		return err
+	}
+
+	data, err := p.deployment()
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{"kustomization.yaml", advanced.Kustomization()},
+		{"configmap.yaml", advanced.KubernetesConfigMap()},
+		{"secret.yaml", advanced.KubernetesSecret()},
+		{"deployment.yaml", advanced.KubernetesDeployment()},
+		{"service.yaml", advanced.KubernetesService()},
+	}
+	for _, file := range files {
+		err = p.render(filepath.Join(projectPath, deployKubernetesPath, file.name), file.template, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateHelmFiles writes a Helm chart installing the same resources
+// as the manifests. Only Chart.yaml and values.yaml are rendered, the
+// templates of the chart are written as they are, for Helm to render
+func (p *Project) CreateHelmFiles(projectPath string) error {
+	templatesPath := filepath.Join(deployHelmPath, "templates")
+	err := p.CreatePath(templatesPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", templatesPath)
+		return err
+	}
+
+	data, err := p.deployment()
+	if err != nil {
+		return err
+	}
+
+	err = p.render(filepath.Join(projectPath, deployHelmPath, "Chart.yaml"), advanced.HelmChart(), data)
+	if err != nil {
+		return err
+	}
+	err = p.render(filepath.Join(projectPath, deployHelmPath, "values.yaml"), advanced.HelmValues(), data)
+	if err != nil {
+		return err
+	}
+
+	templates := []struct {
+		name     string
+		template []byte
+	}{
+		{"_helpers.tpl", advanced.HelmHelpers()},
+		{"configmap.yaml", advanced.HelmConfigMap()},
+		{"secret.yaml", advanced.HelmSecret()},
+		{"deployment.yaml", advanced.HelmDeployment()},
+		{"service.yaml", advanced.HelmService()},
+		{"NOTES.txt", advanced.HelmNotes()},
+	}
+	for _, file := range templates {
+		err = p.writeFile(filepath.Join(projectPath, templatesPath, file.name), file.template)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
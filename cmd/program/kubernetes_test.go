@@ -0,0 +1,558 @@
+package program
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseEnv(t *testing.T) {
+	env := []byte(`PORT=8080
+# A comment
+APP_ENV=local
+
+# Scylla
+BLUEPRINT_DB_HOSTS=localhost:19042 # ScyllaDB Shard-Aware port
+BLUEPRINT_DB_CONSISTENCY="LOCAL_QUORUM"
+# BLUEPRINT_DB_USERNAME=
+BLUEPRINT_DB_PASSWORD=
+BLUEPRINT_DB_URL='./test.db'
+`)
+	expected := []EnvVariable{
+		{Key: "PORT", Value: "8080"},
+		{Key: "APP_ENV", Value: "local"},
+		{Key: "BLUEPRINT_DB_HOSTS", Value: "localhost:19042"},
+		{Key: "BLUEPRINT_DB_CONSISTENCY", Value: "LOCAL_QUORUM"},
+		{Key: "BLUEPRINT_DB_PASSWORD", Value: ""},
+		{Key: "BLUEPRINT_DB_URL", Value: "./test.db"},
+	}
+	if got := parseEnv(env); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateKubernetesFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   flags.Database
+		features []string
+		config   map[string]string
+		secrets  map[string]string
+		ports    []string
+	}{
+		{
+			name:    "postgres",
+			driver:  "postgres",
+			config:  map[string]string{"PORT": "8080", "APP_ENV": "production", "BLUEPRINT_DB_PORT": "5432", "SHUTDOWN_DELAY": "5s"},
+			secrets: map[string]string{"BLUEPRINT_DB_PASSWORD": "change-me"},
+			ports:   []string{"http"},
+		},
+		{
+			name:     "features",
+			driver:   "mongo,redis",
+			features: []string{flags.Grpc, flags.Auth, flags.Metrics, flags.Docker},
+			config:   map[string]string{"GRPC_PORT": "9090", "ADMIN_ADDR": "localhost:6060", "BLUEPRINT_MONGO_HOST": "mongo_bp", "BLUEPRINT_REDIS_DATABASE": "0"},
+			secrets:  map[string]string{"JWT_SECRET": "change-me", "BLUEPRINT_MONGO_ROOT_PASSWORD": "change-me", "BLUEPRINT_REDIS_PASSWORD": ""},
+			ports:    []string{"http", "grpc"},
+		},
+		{
+			name:   "scylla",
+			driver: "scylla",
+			config: map[string]string{"BLUEPRINT_DB_HOSTS": "localhost:19042", "BLUEPRINT_DB_CONSISTENCY": "LOCAL_QUORUM"},
+			ports:  []string{"http"},
+		},
+		{
+			name:   "none",
+			driver: "none",
+			config: map[string]string{"PORT": "8080", "LOG_LEVEL": "info"},
+			ports:  []string{"http"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, memory := newTestProject(flags.Chi, tt.driver, append(tt.features, flags.Kubernetes)...)
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+			if _, err := memory.Stat("/workspace/blueprint/deploy/helm"); err == nil {
+				t.Error("expected no Helm chart without the helm feature")
+			}
+
+			files := readDeployFiles(t, memory, "/workspace/blueprint/deploy/kubernetes")
+			objects := validateManifests(t, files)
+
+			kustomization := objects["Kustomization"][0].(*kustomization)
+			for name := range files {
+				if name != "kustomization.yaml" && !slices.Contains(kustomization.Resources, name) {
+					t.Errorf("expected kustomization.yaml to list %s, got %v", name, kustomization.Resources)
+				}
+			}
+
+			assertEnv(t, objects, tt.config, tt.secrets)
+
+			container := objects["Deployment"][0].(*deployment).Spec.Template.Spec.Containers[0]
+			var ports []string
+			for _, port := range container.Ports {
+				ports = append(ports, port.Name)
+			}
+			if !slices.Equal(ports, tt.ports) {
+				t.Errorf("expected the container ports %v, got %v", tt.ports, ports)
+			}
+			if container.LivenessProbe.HTTPGet.Path != "/livez" || container.ReadinessProbe.HTTPGet.Path != "/readyz" {
+				t.Errorf("expected the probes on /livez and /readyz, got %+v and %+v", container.LivenessProbe.HTTPGet, container.ReadinessProbe.HTTPGet)
+			}
+
+			annotations := objects["Deployment"][0].(*deployment).Spec.Template.Metadata.Annotations
+			if scrape := annotations["prometheus.io/scrape"]; (scrape == "true") != slices.Contains(tt.features, flags.Metrics) {
+				t.Errorf("expected the pods to be scraped with the metrics feature only, got %v", annotations)
+			}
+		})
+	}
+}
+
+func TestCreateHelmFiles(t *testing.T) {
+	project, memory := newTestProject(flags.Gin, "postgres", flags.Helm, flags.Grpc, flags.Metrics)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project: %v", err)
+	}
+	if !project.AdvancedOptions[flags.Kubernetes] {
+		t.Error("expected the helm feature to select the kubernetes feature")
+	}
+	if _, err := memory.Stat("/workspace/blueprint/deploy/kubernetes/deployment.yaml"); err != nil {
+		t.Errorf("expected the Kubernetes manifests along with the chart: %v", err)
+	}
+
+	chart := readDeployFiles(t, memory, "/workspace/blueprint/deploy/helm")
+	for _, name := range []string{"Chart.yaml", "values.yaml", "templates/_helpers.tpl", "templates/deployment.yaml", "templates/NOTES.txt"} {
+		if _, ok := chart[name]; !ok {
+			t.Errorf("expected the chart to contain %s", name)
+		}
+	}
+
+	// The templates are rendered by helm lint and helm template in CI,
+	// the values they are rendered with come from the project
+	var meta struct {
+		APIVersion  string `yaml:"apiVersion"`
+		Name        string `yaml:"name"`
+		Description string `yaml:"description"`
+		Type        string `yaml:"type"`
+		Version     string `yaml:"version"`
+		AppVersion  string `yaml:"appVersion"`
+	}
+	decodeStrict(t, "Chart.yaml", chart["Chart.yaml"], &meta)
+	if meta.APIVersion != "v2" || meta.Name != "blueprint" || meta.Type != "application" {
+		t.Errorf("unexpected Chart.yaml %+v", meta)
+	}
+
+	var values struct {
+		ReplicaCount int `yaml:"replicaCount"`
+		Image        struct {
+			Repository string `yaml:"repository"`
+			Tag        string `yaml:"tag"`
+			PullPolicy string `yaml:"pullPolicy"`
+		} `yaml:"image"`
+		Service struct {
+			Type string `yaml:"type"`
+			Port int    `yaml:"port"`
+		} `yaml:"service"`
+		Ports   map[string]int    `yaml:"ports"`
+		Config  map[string]string `yaml:"config"`
+		Secrets map[string]string `yaml:"secrets"`
+		Metrics struct {
+			Enabled bool `yaml:"enabled"`
+		} `yaml:"metrics"`
+		Resources map[string]map[string]string `yaml:"resources"`
+	}
+	decodeStrict(t, "values.yaml", chart["values.yaml"], &values)
+	if values.Ports["http"] != 8080 || values.Ports["grpc"] != 9090 {
+		t.Errorf("expected the http and grpc ports of .env, got %v", values.Ports)
+	}
+	if values.Config["APP_ENV"] != "production" || values.Config["BLUEPRINT_DB_HOST"] != "localhost" {
+		t.Errorf("expected the settings of .env, got %v", values.Config)
+	}
+	if _, ok := values.Config["BLUEPRINT_DB_PASSWORD"]; ok || values.Secrets["BLUEPRINT_DB_PASSWORD"] != "change-me" {
+		t.Errorf("expected the password in the secrets only, got %v and %v", values.Config, values.Secrets)
+	}
+	if !values.Metrics.Enabled {
+		t.Error("expected the pods to be scraped with the metrics feature")
+	}
+}
+
+// decodeStrict decodes the YAML content of name into v, failing
+// the test on fields v does not have
+func decodeStrict(t *testing.T, name string, content []byte, v any) {
+	t.Helper()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil {
+		t.Fatalf("invalid %s: %v", name, err)
+	}
+}
+
+// readDeployFiles returns the generated files under dir, keyed by
+// their path relative to dir
+func readDeployFiles(t *testing.T, memory *filesystem.Memory, dir string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	for _, name := range memory.Paths() {
+		rel, ok := strings.CutPrefix(name, dir+"/")
+		if !ok {
+			continue
+		}
+		content, err := memory.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[rel] = content
+	}
+	if len(files) == 0 {
+		t.Fatalf("expected files in %s", dir)
+	}
+	return files
+}
+
+// The fields of the Kubernetes objects the manifests use. The manifests
+// are decoded strictly, so that a misspelled or misplaced field fails
+// like it does with "kubectl apply --dry-run=client"
+
+type objectMeta struct {
+	Name        string         `yaml:"name"`
+	Labels      map[string]any `yaml:"labels"`
+	Annotations map[string]any `yaml:"annotations"`
+}
+
+type typeMeta struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+}
+
+type configMap struct {
+	typeMeta `yaml:",inline"`
+	Metadata objectMeta     `yaml:"metadata"`
+	Data     map[string]any `yaml:"data"`
+}
+
+type secret struct {
+	typeMeta   `yaml:",inline"`
+	Metadata   objectMeta     `yaml:"metadata"`
+	Type       string         `yaml:"type"`
+	Data       map[string]any `yaml:"data"`
+	StringData map[string]any `yaml:"stringData"`
+}
+
+type servicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort string `yaml:"targetPort"`
+	Protocol   string `yaml:"protocol"`
+}
+
+type service struct {
+	typeMeta `yaml:",inline"`
+	Metadata objectMeta `yaml:"metadata"`
+	Spec     struct {
+		Type     string         `yaml:"type"`
+		Selector map[string]any `yaml:"selector"`
+		Ports    []servicePort  `yaml:"ports"`
+	} `yaml:"spec"`
+}
+
+type probe struct {
+	HTTPGet *struct {
+		Path string `yaml:"path"`
+		Port string `yaml:"port"`
+	} `yaml:"httpGet"`
+	InitialDelaySeconds int `yaml:"initialDelaySeconds"`
+	PeriodSeconds       int `yaml:"periodSeconds"`
+	TimeoutSeconds      int `yaml:"timeoutSeconds"`
+	FailureThreshold    int `yaml:"failureThreshold"`
+}
+
+type reference struct {
+	Name string `yaml:"name"`
+}
+
+type container struct {
+	Name            string `yaml:"name"`
+	Image           string `yaml:"image"`
+	ImagePullPolicy string `yaml:"imagePullPolicy"`
+	Ports           []struct {
+		Name          string `yaml:"name"`
+		ContainerPort int    `yaml:"containerPort"`
+		Protocol      string `yaml:"protocol"`
+	} `yaml:"ports"`
+	EnvFrom []struct {
+		ConfigMapRef *reference `yaml:"configMapRef"`
+		SecretRef    *reference `yaml:"secretRef"`
+	} `yaml:"envFrom"`
+	LivenessProbe  *probe `yaml:"livenessProbe"`
+	ReadinessProbe *probe `yaml:"readinessProbe"`
+	Resources      struct {
+		Requests map[string]string `yaml:"requests"`
+		Limits   map[string]string `yaml:"limits"`
+	} `yaml:"resources"`
+	SecurityContext struct {
+		AllowPrivilegeEscalation *bool `yaml:"allowPrivilegeEscalation"`
+	} `yaml:"securityContext"`
+}
+
+type deployment struct {
+	typeMeta `yaml:",inline"`
+	Metadata objectMeta `yaml:"metadata"`
+	Spec     struct {
+		Replicas int `yaml:"replicas"`
+		Selector struct {
+			MatchLabels map[string]any `yaml:"matchLabels"`
+		} `yaml:"selector"`
+		Template struct {
+			Metadata objectMeta `yaml:"metadata"`
+			Spec     struct {
+				TerminationGracePeriodSeconds int         `yaml:"terminationGracePeriodSeconds"`
+				Containers                    []container `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+}
+
+type kustomization struct {
+	typeMeta  `yaml:",inline"`
+	Resources []string `yaml:"resources"`
+	Images    []struct {
+		Name   string `yaml:"name"`
+		NewTag string `yaml:"newTag"`
+	} `yaml:"images"`
+}
+
+var (
+	dnsLabel   = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	labelValue = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+	configKey  = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+	portName   = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	kinds      = map[string]string{
+		"ConfigMap":     "v1",
+		"Secret":        "v1",
+		"Service":       "v1",
+		"Deployment":    "apps/v1",
+		"Kustomization": "kustomize.config.k8s.io/v1beta1",
+	}
+)
+
+// validateManifests decodes the objects of the manifests strictly,
+// checks them against the rules of the API server, and that the
+// objects they refer to exist. It returns the objects by kind
+func validateManifests(t *testing.T, files map[string][]byte) map[string][]any {
+	t.Helper()
+
+	objects := make(map[string][]any)
+	for name, content := range files {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			var node yaml.Node
+			err := decoder.Decode(&node)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s is not valid YAML: %v\n%s", name, err, content)
+			}
+
+			var meta typeMeta
+			if err := node.Decode(&meta); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if apiVersion, ok := kinds[meta.Kind]; !ok || apiVersion != meta.APIVersion {
+				t.Errorf("%s: unexpected kind %s in version %q", name, meta.Kind, meta.APIVersion)
+				continue
+			}
+
+			var object any
+			switch meta.Kind {
+			case "ConfigMap":
+				object = &configMap{}
+			case "Secret":
+				object = &secret{}
+			case "Service":
+				object = &service{}
+			case "Deployment":
+				object = &deployment{}
+			case "Kustomization":
+				object = &kustomization{}
+			}
+			var buf bytes.Buffer
+			if err := yaml.NewEncoder(&buf).Encode(&node); err != nil {
+				t.Fatal(err)
+			}
+			strict := yaml.NewDecoder(&buf)
+			strict.KnownFields(true)
+			if err := strict.Decode(object); err != nil {
+				t.Errorf("%s: invalid %s: %v\n%s", name, meta.Kind, err, content)
+				continue
+			}
+			objects[meta.Kind] = append(objects[meta.Kind], object)
+		}
+	}
+
+	for _, kind := range []string{"ConfigMap", "Secret", "Service", "Deployment"} {
+		if len(objects[kind]) != 1 {
+			t.Fatalf("expected a %s, got %d", kind, len(objects[kind]))
+		}
+	}
+
+	cm := objects["ConfigMap"][0].(*configMap)
+	s := objects["Secret"][0].(*secret)
+	svc := objects["Service"][0].(*service)
+	d := objects["Deployment"][0].(*deployment)
+
+	validateMeta(t, "ConfigMap", cm.Metadata)
+	validateMeta(t, "Secret", s.Metadata)
+	validateMeta(t, "Service", svc.Metadata)
+	validateMeta(t, "Deployment", d.Metadata)
+	validateStrings(t, "ConfigMap data", cm.Data, configKey, nil)
+	validateStrings(t, "Secret stringData", s.StringData, configKey, nil)
+	if len(s.Data) > 0 {
+		t.Errorf("expected the secrets in stringData, got data %v", s.Data)
+	}
+
+	pod := d.Spec.Template
+	validateStrings(t, "pod labels", pod.Metadata.Labels, nil, labelValue)
+	validateStrings(t, "pod annotations", pod.Metadata.Annotations, nil, nil)
+	selector := validateStrings(t, "Deployment selector", d.Spec.Selector.MatchLabels, nil, labelValue)
+	if len(selector) == 0 {
+		t.Error("expected the Deployment to select its pods")
+	}
+	for key, value := range selector {
+		if pod.Metadata.Labels[key] != value {
+			t.Errorf("expected the pods to have the label %s=%s of the selector, got %v", key, value, pod.Metadata.Labels)
+		}
+	}
+	if d.Spec.Replicas < 1 {
+		t.Errorf("expected at least a replica, got %d", d.Spec.Replicas)
+	}
+	if len(pod.Spec.Containers) == 0 {
+		t.Fatal("expected a container")
+	}
+
+	ports := make(map[string]int)
+	for _, c := range pod.Spec.Containers {
+		if !dnsLabel.MatchString(c.Name) || c.Image == "" {
+			t.Errorf("invalid container %q running %q", c.Name, c.Image)
+		}
+		for _, port := range c.Ports {
+			if !portName.MatchString(port.Name) || len(port.Name) > 15 || port.ContainerPort < 1 || port.ContainerPort > 65535 {
+				t.Errorf("invalid container port %s: %d", port.Name, port.ContainerPort)
+			}
+			ports[port.Name] = port.ContainerPort
+		}
+		for _, p := range []*probe{c.LivenessProbe, c.ReadinessProbe} {
+			if p == nil || p.HTTPGet == nil {
+				t.Errorf("expected the container %s to be probed over HTTP", c.Name)
+				continue
+			}
+			if _, ok := ports[p.HTTPGet.Port]; !ok {
+				t.Errorf("expected the probe of %s on a port of the container, got %q", p.HTTPGet.Path, p.HTTPGet.Port)
+			}
+		}
+		for _, env := range c.EnvFrom {
+			switch {
+			case env.ConfigMapRef != nil && env.ConfigMapRef.Name != cm.Metadata.Name:
+				t.Errorf("expected the ConfigMap %s, got %s", cm.Metadata.Name, env.ConfigMapRef.Name)
+			case env.SecretRef != nil && env.SecretRef.Name != s.Metadata.Name:
+				t.Errorf("expected the Secret %s, got %s", s.Metadata.Name, env.SecretRef.Name)
+			case env.ConfigMapRef == nil && env.SecretRef == nil:
+				t.Error("expected envFrom to refer to a ConfigMap or a Secret")
+			}
+		}
+		if len(c.EnvFrom) != 2 {
+			t.Errorf("expected the container to get its environment from the ConfigMap and the Secret, got %d sources", len(c.EnvFrom))
+		}
+	}
+
+	if len(svc.Metadata.Name) > 63 || !dnsLabel.MatchString(svc.Metadata.Name) {
+		t.Errorf("invalid Service name %q", svc.Metadata.Name)
+	}
+	for key, value := range validateStrings(t, "Service selector", svc.Spec.Selector, nil, labelValue) {
+		if pod.Metadata.Labels[key] != value {
+			t.Errorf("expected the Service to select the pods of the Deployment, got %s=%s", key, value)
+		}
+	}
+	for _, port := range svc.Spec.Ports {
+		if _, ok := ports[port.TargetPort]; !ok || port.Port < 1 || port.Port > 65535 {
+			t.Errorf("invalid Service port %s: %d to %q", port.Name, port.Port, port.TargetPort)
+		}
+	}
+
+	return objects
+}
+
+// validateMeta checks the name and the labels of an object
+func validateMeta(t *testing.T, kind string, meta objectMeta) {
+	t.Helper()
+
+	if len(meta.Name) > 253 || !dnsLabel.MatchString(meta.Name) {
+		t.Errorf("invalid %s name %q", kind, meta.Name)
+	}
+	validateStrings(t, kind+" labels", meta.Labels, nil, labelValue)
+	validateStrings(t, kind+" annotations", meta.Annotations, nil, nil)
+}
+
+// validateStrings checks that the values of m are strings, like the
+// API server requires for labels, annotations and the data of
+// ConfigMaps, and that the keys and values match their patterns
+func validateStrings(t *testing.T, field string, m map[string]any, keys, values *regexp.Regexp) map[string]string {
+	t.Helper()
+
+	strs := make(map[string]string, len(m))
+	for key, value := range m {
+		s, ok := value.(string)
+		if !ok {
+			t.Errorf("%s: %s must be a string, got %T %v", field, key, value, value)
+			continue
+		}
+		if keys != nil && !keys.MatchString(key) {
+			t.Errorf("%s: invalid key %q", field, key)
+		}
+		if values != nil && (len(s) > 63 || !values.MatchString(s)) {
+			t.Errorf("%s: invalid value %q of %s", field, s, key)
+		}
+		strs[key] = s
+	}
+	return strs
+}
+
+// assertEnv checks the variables of the ConfigMap and the Secret,
+// and that no variable is in both
+func assertEnv(t *testing.T, objects map[string][]any, config, secrets map[string]string) {
+	t.Helper()
+
+	data := objects["ConfigMap"][0].(*configMap).Data
+	stringData := objects["Secret"][0].(*secret).StringData
+	for key, want := range config {
+		if got, ok := data[key]; !ok || got != want {
+			t.Errorf("expected %s=%q in the ConfigMap, got %v", key, want, got)
+		}
+	}
+	for key, want := range secrets {
+		if got, ok := stringData[key]; !ok || got != want {
+			t.Errorf("expected %s=%q in the Secret, got %v", key, want, got)
+		}
+	}
+	for key := range stringData {
+		if _, ok := data[key]; ok {
+			t.Errorf("expected %s in the Secret only", key)
+		}
+	}
+	if len(secrets) == 0 && len(stringData) > 0 {
+		t.Errorf("expected no secrets, got %v", stringData)
+	}
+}
@@ -0,0 +1,135 @@
+package program
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of the manifest written at
+// the root of every generated project
+const ManifestFile = ".blueprint.yaml"
+
+const manifestHeader = "# Generated by go-blueprint, describes how this project was generated.\n# It is read by go-blueprint to tell generated files from the ones changed since.\n"
+
+// A Manifest records the options a project was generated
+// with, and the checksum of every file that was generated
+type Manifest struct {
+	Version   string            `yaml:"version"`
+	Name      string            `yaml:"name"`
+	Framework flags.Framework   `yaml:"framework"`
+	Driver    flags.Database    `yaml:"driver"`
+	ORM       flags.ORM         `yaml:"orm,omitempty"`
+	Features  []string          `yaml:"features"`
+	Git       flags.Git         `yaml:"git"`
+	OpenAPI   string            `yaml:"openapi,omitempty"` // Path of the OpenAPI document in the project
+	Files     map[string]string `yaml:"files"`
+}
+
+// ReadManifest reads the manifest of the project at projectPath
+// from the given file system
+func ReadManifest(fsys filesystem.FileSystem, projectPath string) (*Manifest, error) {
+	content, err := fsys.ReadFile(filepath.Join(projectPath, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]string)
+	}
+
+	return manifest, nil
+}
+
+// Marshal returns the YAML representation of the manifest
+func (m *Manifest) Marshal() ([]byte, error) {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(manifestHeader), content...), nil
+}
+
+// Unmodified reports whether the file at the slash separated path
+// relative to the project still has the content it was generated with
+func (m *Manifest) Unmodified(name string, content []byte) bool {
+	checksum, ok := m.Files[name]
+	return ok && checksum == Checksum(content)
+}
+
+// Checksum returns the checksum recorded in
+// a manifest for the given file content
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// writeManifest writes the manifest of the project, with the
+// checksum of every file generated so far. go.mod and go.sum
+// are left out, as they are maintained by the go command
+func (p *Project) writeManifest(projectPath string, features []string) error {
+	manifest := &Manifest{
+		Version:   p.BlueprintVersion,
+		Name:      p.ProjectName,
+		Framework: p.ProjectType,
+		Driver:    p.DBDriver,
+		Features:  features,
+		Git:       p.GitOptions,
+		Files:     make(map[string]string),
+	}
+	if len(p.ORMStores()) > 0 {
+		manifest.ORM = p.ORM
+	}
+	if p.OpenAPI != nil {
+		manifest.OpenAPI = p.openAPIPath()
+	}
+
+	for _, name := range p.files {
+		rel, err := filepath.Rel(projectPath, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if slices.Contains([]string{"go.mod", "go.sum", ManifestFile}, rel) {
+			continue
+		}
+
+		content, err := p.FS.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		manifest.Files[rel] = Checksum(content)
+	}
+
+	content, err := manifest.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return p.FS.WriteFile(filepath.Join(projectPath, ManifestFile), content, 0o644)
+}
+
+// enabledFeatures returns the advanced features
+// selected for the project, sorted by name
+func (p *Project) enabledFeatures() []string {
+	features := []string{}
+	for feature, enabled := range p.AdvancedOptions {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	slices.Sort(features)
+
+	return features
+}
@@ -0,0 +1,72 @@
+package program
+
+import (
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// defaultGoVersion is written to go.mod when the version of the
+// running toolchain is not a release, e.g. a devel build
+const defaultGoVersion = "1.23"
+
+// writeGoMod writes the go.mod "go mod init" would create, for
+// projects that are not generated on disk. Requirements are left
+// for "go mod tidy" to resolve once the project is extracted
+func (p *Project) writeGoMod(projectPath string) error {
+	goVersion := strings.TrimPrefix(runtime.Version(), "go")
+	if goVersion == runtime.Version() || strings.ContainsAny(goVersion, " +") {
+		goVersion = defaultGoVersion
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo %s\n", p.ProjectName, goVersion)
+	return p.writeFile(filepath.Join(projectPath, "go.mod"), []byte(goMod))
+}
+
+// appendGoModReplace adds the replace directive "go mod edit -replace"
+// would add, given a payload such as
+// github.com/gocql/gocql=github.com/scylladb/gocql@v1.14.4
+func (p *Project) appendGoModReplace(projectPath string, replace string) error {
+	oldPath, newPath, ok := strings.Cut(replace, "=")
+	if !ok {
+		return fmt.Errorf("invalid replace payload '%s'", replace)
+	}
+	if module, version, ok := strings.Cut(newPath, "@"); ok {
+		newPath = module + " " + version
+	}
+
+	goModPath := filepath.Join(projectPath, "go.mod")
+	goMod, err := p.FS.ReadFile(goModPath)
+	if err != nil {
+		return err
+	}
+
+	goMod = fmt.Appendf(goMod, "\nreplace %s => %s\n", oldPath, newPath)
+	return p.writeFile(goModPath, goMod)
+}
+
+// formatGoFiles formats every generated Go file the way gofmt
+// would, for projects that are not generated on disk
+func (p *Project) formatGoFiles() error {
+	for _, name := range p.files {
+		if filepath.Ext(name) != ".go" {
+			continue
+		}
+
+		content, err := p.FS.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := p.FS.WriteFile(name, formatted, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -10,16 +10,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"text/template"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
 	"github.com/melkeydev/go-blueprint/cmd/flags"
-	tpl "github.com/melkeydev/go-blueprint/cmd/template"
+	"github.com/melkeydev/go-blueprint/cmd/openapi"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 	"github.com/melkeydev/go-blueprint/cmd/template/dbdriver"
-	"github.com/melkeydev/go-blueprint/cmd/template/docker"
 	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+	"github.com/melkeydev/go-blueprint/cmd/template/orm"
 	"github.com/melkeydev/go-blueprint/cmd/utils"
 )
 
@@ -31,7 +33,7 @@ type Project struct {
 	AbsolutePath      string
 	ProjectType       flags.Framework
 	DBDriver          flags.Database
-	Docker            flags.Database
+	ORM               flags.ORM
 	FrameworkMap      map[flags.Framework]Framework
 	DBDriverMap       map[flags.Database]Driver
 	DockerMap         map[flags.Database]Docker
@@ -39,6 +41,23 @@ type Project struct {
 	AdvancedTemplates AdvancedTemplates
 	GitOptions        flags.Git
 	OSCheck           map[string]bool
+	BlueprintVersion  string
+
+	// OpenAPI is the document the routes and handlers are
+	// generated from, in place of the hello world handler
+	OpenAPI *openapi.Spec
+
+	// FS is where the project files are written, the operating
+	// system when left empty. External commands such as go get or
+	// git only run against the operating system; otherwise they
+	// are recorded into SkippedCommands
+	FS              filesystem.FileSystem
+	SkippedCommands []string
+
+	// files holds the path of every file written for the project
+	files []string
+	// manifest is the manifest of an existing project
+	manifest *Manifest
 }
 
 type AdvancedTemplates struct {
@@ -73,14 +92,88 @@ type Templater interface {
 	HtmxTemplRoutes() []byte
 	HtmxTemplImports() []byte
 	WebsocketImports() []byte
+	LoggerMiddleware() []byte
+}
+
+// A TitledTemplater is a Templater naming its framework in the
+// interactive steps, when the capitalized name does not spell it
+type TitledTemplater interface {
+	Title() string
+}
+
+// An OpenAPITemplater is a Templater able to generate the handlers
+// of an OpenAPI document, and the tests sending them a request
+type OpenAPITemplater interface {
+	OpenAPIHandlers() []byte
+	OpenAPITests() []byte
+}
+
+// A ResourceTemplater is a Templater able to generate the CRUD handlers
+// of a resource and their tests. ResourceRouter returns the router of
+// RegisterRoutes the routes of the resources are registered on
+type ResourceTemplater interface {
+	ResourceHandlers() []byte
+	ResourceTests() []byte
+	ResourceRouter() string
+}
+
+// An AuthTemplater is a Templater able to generate the handlers
+// and the middleware of the auth feature, and their tests
+type AuthTemplater interface {
+	AuthHandlers() []byte
+	AuthTests() []byte
 }
 
 type DBDriverTemplater interface {
 	Service() []byte
 	Env() []byte
+	Config() []byte
+	Tests() []byte
+}
+
+// A MigrationsTemplater is a DBDriverTemplater of a SQL database,
+// able to apply the migrations of the migrations feature
+type MigrationsTemplater interface {
+	Migrate() []byte
+}
+
+// A QueriesTemplater is a DBDriverTemplater of a SQL database, with
+// the sqlc.yaml entry, example queries and code generated from them
+// of the sqlc feature
+type QueriesTemplater interface {
+	Sqlc() []byte
+	Queries() []byte
+	QueriesModels() []byte
+	QueriesCode() []byte
+}
+
+// A RepositoryTemplater is a DBDriverTemplater able to store resources.
+// Repository is the model and repository of a resource, and
+// RepositoryBlocks redefines the blocks of
+// dbdriver.RepositoryBlocksTemplate, like the type of its ids
+type RepositoryTemplater interface {
+	Repository() []byte
+	RepositoryBlocks() []byte
+}
+
+// An ORMTemplater provides the templates of an ORM. Service and Tests
+// redefine the blocks of orm.BlocksTemplate, completing the service
+// and the tests of the SQL drivers with the ORM
+type ORMTemplater interface {
+	Service() []byte
 	Tests() []byte
 }
 
+// An ORMCodegenTemplater is an ORMTemplater of an ORM generating its
+// client from a schema, like ent. The example schema, the package
+// generating the client and the file requiring the generator are
+// written to the directory named after it
+type ORMCodegenTemplater interface {
+	Schema() []byte
+	Generate() []byte
+	Tools() []byte
+}
+
 type DockerTemplater interface {
 	Docker() []byte
 }
@@ -107,17 +200,62 @@ var (
 	gocqlDriver    = []string{"github.com/gocql/gocql"}
 	scyllaDriver   = "github.com/scylladb/gocql@v1.14.4" // Replacement for GoCQL
 
+	entPackage  = []string{"entgo.io/ent", "entgo.io/ent/cmd/ent"}
+	sqlxPackage = []string{"github.com/jmoiron/sqlx"}
+
 	godotenvPackage = []string{"github.com/joho/godotenv"}
+	migratePackage  = []string{"github.com/golang-migrate/migrate/v4"}
 	templPackage    = []string{"github.com/a-h/templ"}
+	grpcPackage     = []string{"google.golang.org/grpc", "google.golang.org/protobuf"}
+	gqlgenPackage   = []string{"github.com/99designs/gqlgen"}
+	authPackages    = []string{"github.com/golang-jwt/jwt/v5", "golang.org/x/crypto"}
+	otelPackages    = []string{
+		"go.opentelemetry.io/otel",
+		"go.opentelemetry.io/otel/sdk",
+		"go.opentelemetry.io/otel/sdk/metric",
+		"go.opentelemetry.io/otel/log",
+		"go.opentelemetry.io/otel/sdk/log",
+		"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp",
+		"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp",
+		"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp",
+		"go.opentelemetry.io/contrib/bridges/otelslog",
+	}
+	otelsqlPackage = []string{"github.com/XSAM/otelsql"}
+	// otelHTTPPackages are the middlewares instrumenting the routes of
+	// the frameworks with one of their own. The others use otelhttp
+	otelHTTPPackages = map[flags.Framework][]string{
+		flags.Gin:        {"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"},
+		flags.Fiber:      {"github.com/gofiber/contrib/otelfiber/v2"},
+		flags.GorillaMux: {"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"},
+	}
+	otelhttpPackage = []string{"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"}
+	metricsPackage  = []string{"github.com/prometheus/client_golang"}
 )
 
 const (
-	root                 = "/"
-	cmdApiPath           = "cmd/api"
-	cmdWebPath           = "cmd/web"
-	internalServerPath   = "internal/server"
-	internalDatabasePath = "internal/database"
-	gitHubActionPath     = ".github/workflows"
+	root                  = "/"
+	apiPath               = "api"
+	cmdApiPath            = "cmd/api"
+	cmdMigratePath        = "cmd/migrate"
+	cmdWebPath            = "cmd/web"
+	internalApiPath       = "internal/api"
+	internalServerPath    = "internal/server"
+	internalDatabasePath  = "internal/database"
+	internalGrpcPath      = "internal/grpc"
+	internalGraphPath     = "internal/graph"
+	internalAuthPath      = "internal/auth"
+	internalTelemetryPath = "internal/telemetry"
+	internalMetricsPath   = "internal/metrics"
+	internalLoggerPath    = "internal/logger"
+	internalConfigPath    = "internal/config"
+	internalHealthPath    = "internal/health"
+	migrationsPath        = "migrations"
+	queriesPath           = "queries"
+	schemaPath            = "schema"
+	protoPath             = "proto"
+	gitHubActionPath      = ".github/workflows"
+	deployKubernetesPath  = "deploy/kubernetes"
+	deployHelmPath        = "deploy/helm"
 )
 
 // CheckOs checks Operation system and generates MakeFile and `go build` command
@@ -151,109 +289,67 @@ func (p *Project) ExitCLI(tprogram *tea.Program) {
 // createFrameWorkMap adds the current supported
 // Frameworks into a Project's FrameworkMap
 func (p *Project) createFrameworkMap() {
-	p.FrameworkMap[flags.Chi] = Framework{
-		packageName: chiPackage,
-		templater:   framework.ChiTemplates{},
-	}
-
-	p.FrameworkMap[flags.StandardLibrary] = Framework{
-		packageName: []string{},
-		templater:   framework.StandardLibTemplate{},
-	}
-
-	p.FrameworkMap[flags.Gin] = Framework{
-		packageName: ginPackage,
-		templater:   framework.GinTemplates{},
-	}
-
-	p.FrameworkMap[flags.Fiber] = Framework{
-		packageName: fiberPackage,
-		templater:   framework.FiberTemplates{},
-	}
-
-	p.FrameworkMap[flags.GorillaMux] = Framework{
-		packageName: gorillaPackage,
-		templater:   framework.GorillaTemplates{},
-	}
-
-	p.FrameworkMap[flags.HttpRouter] = Framework{
-		packageName: routerPackage,
-		templater:   framework.RouterTemplates{},
-	}
-
-	p.FrameworkMap[flags.Echo] = Framework{
-		packageName: echoPackage,
-		templater:   framework.EchoTemplates{},
+	for _, registered := range frameworks {
+		p.FrameworkMap[registered.Name] = Framework{
+			packageName: registered.Packages,
+			templater:   registered.Templater,
+		}
 	}
 }
 
+// createDBDriverMap adds the registered drivers
+// into a Project's DBDriverMap
 func (p *Project) createDBDriverMap() {
-	p.DBDriverMap[flags.MySql] = Driver{
-		packageName: mysqlDriver,
-		templater:   dbdriver.MysqlTemplate{},
-	}
-	p.DBDriverMap[flags.Postgres] = Driver{
-		packageName: postgresDriver,
-		templater:   dbdriver.PostgresTemplate{},
-	}
-	p.DBDriverMap[flags.Sqlite] = Driver{
-		packageName: sqliteDriver,
-		templater:   dbdriver.SqliteTemplate{},
-	}
-	p.DBDriverMap[flags.Mongo] = Driver{
-		packageName: mongoDriver,
-		templater:   dbdriver.MongoTemplate{},
-	}
-	p.DBDriverMap[flags.Redis] = Driver{
-		packageName: redisDriver,
-		templater:   dbdriver.RedisTemplate{},
-	}
-
-	p.DBDriverMap[flags.Scylla] = Driver{
-		packageName: gocqlDriver,
-		templater:   dbdriver.ScyllaTemplate{},
+	for _, registered := range drivers {
+		p.DBDriverMap[registered.Name] = Driver{
+			packageName: registered.Packages,
+			templater:   registered.Templater,
+		}
 	}
 }
 
+// createDockerMap adds the registered drivers running
+// in docker-compose into a Project's DockerMap
 func (p *Project) createDockerMap() {
 	p.DockerMap = make(map[flags.Database]Docker)
 
-	p.DockerMap[flags.MySql] = Docker{
-		packageName: []string{},
-		templater:   docker.MysqlDockerTemplate{},
-	}
-	p.DockerMap[flags.Postgres] = Docker{
-		packageName: []string{},
-		templater:   docker.PostgresDockerTemplate{},
-	}
-	p.DockerMap[flags.Mongo] = Docker{
-		packageName: []string{},
-		templater:   docker.MongoDockerTemplate{},
-	}
-	p.DockerMap[flags.Redis] = Docker{
-		packageName: []string{},
-		templater:   docker.RedisDockerTemplate{},
-	}
-	p.DockerMap[flags.Scylla] = Docker{
-		packageName: []string{},
-		templater:   docker.ScyllaDockerTemplate{},
+	for _, registered := range drivers {
+		if registered.SupportsCompose() {
+			p.DockerMap[registered.Name] = Docker{
+				packageName: []string{},
+				templater:   registered.Compose,
+			}
+		}
 	}
 }
 
 // CreateMainFile creates the project folders and files,
 // and writes to them depending on the selected options
 func (p *Project) CreateMainFile() error {
+	if p.FS == nil {
+		p.FS = filesystem.OS{}
+	}
+
+	// The selected features are recorded before generation
+	// turns some of them on or off
+	features := p.enabledFeatures()
+
+	// The Helm chart installs the resources of the Kubernetes manifests
+	if p.AdvancedOptions[flags.Helm] {
+		p.AdvancedOptions[flags.Kubernetes] = true
+	}
+
 	// check if AbsolutePath exists
-	if _, err := os.Stat(p.AbsolutePath); os.IsNotExist(err) {
+	if _, err := p.FS.Stat(p.AbsolutePath); os.IsNotExist(err) {
 		// create directory
-		if err := os.Mkdir(p.AbsolutePath, 0o754); err != nil {
+		if err := p.FS.MkdirAll(p.AbsolutePath, 0o754); err != nil {
 			log.Printf("Could not create directory: %v", err)
 			return err
 		}
 	}
 
 	// Check if user.email is set.
-	if p.GitOptions.String() != flags.Skip {
+	if p.GitOptions.String() != flags.Skip && p.onDisk() {
 
 		emailSet, err := utils.CheckGitConfig("user.email")
 		if err != nil {
@@ -270,12 +366,10 @@ func (p *Project) CreateMainFile() error {
 
 	// Create a new directory with the project name
 	projectPath := filepath.Join(p.AbsolutePath, utils.GetRootDir(p.ProjectName))
-	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-		err := os.MkdirAll(projectPath, 0o751)
-		if err != nil {
-			log.Printf("Error creating root project directory %v\n", err)
-			return err
-		}
+	err := p.FS.MkdirAll(projectPath, 0o751)
+	if err != nil {
+		log.Printf("Error creating root project directory %v\n", err)
+		return err
 	}
 
 	// Define Operating system
@@ -284,8 +378,28 @@ func (p *Project) CreateMainFile() error {
 	// Create the map for our program
 	p.createFrameworkMap()
 
+	if _, ok := p.FrameworkMap[p.ProjectType].templater.(OpenAPITemplater); p.OpenAPI != nil && !ok {
+		return fmt.Errorf("framework '%s' does not support OpenAPI documents", p.ProjectType)
+	}
+
+	if p.ORM != "" && p.ORM != flags.NoORM && len(p.ORMStores()) == 0 {
+		return fmt.Errorf("ORM '%s' does not support the database '%s'", p.ORM, p.DBDriver)
+	}
+
+	if p.AdvancedOptions[flags.Migrations] && len(p.MigratedStores()) == 0 {
+		return fmt.Errorf("the %s feature needs a SQL database driver, got '%s'", flags.Migrations, p.DBDriver)
+	}
+
+	if p.AdvancedOptions[flags.Sqlc] && len(p.QueriedStores()) == 0 {
+		return fmt.Errorf("the %s feature needs a SQL database driver, got '%s'", flags.Sqlc, p.DBDriver)
+	}
+
 	// Create go.mod
-	err := utils.InitGoMod(p.ProjectName, projectPath)
+	if p.onDisk() {
+		err = utils.InitGoMod(p.ProjectName, projectPath)
+	} else {
+		err = p.writeGoMod(projectPath)
+	}
 	if err != nil {
 		log.Printf("Could not initialize go.mod in new project %v\n", err)
 		return err
@@ -293,110 +407,138 @@ func (p *Project) CreateMainFile() error {
 
 	// Install the correct package for the selected framework
 	if p.ProjectType != flags.StandardLibrary {
-		err = utils.GoGetPackage(projectPath, p.FrameworkMap[p.ProjectType].packageName)
+		err = p.goGetPackage(projectPath, p.FrameworkMap[p.ProjectType].packageName)
 		if err != nil {
 			log.Println("Could not install go dependency for the chosen framework")
 			return err
 		}
 	}
 
-	// Install the correct package for the selected driver
+	// Install the correct packages for the selected drivers
 	if p.DBDriver != "none" {
 		p.createDBDriverMap()
-		err = utils.GoGetPackage(projectPath, p.DBDriverMap[p.DBDriver].packageName)
+		p.createDockerMap()
+	}
+	for _, store := range p.Stores() {
+		err = p.CreateStoreFiles(store, projectPath)
 		if err != nil {
-			log.Println("Could not install go dependency for chosen driver")
 			return err
 		}
+	}
 
-		err = p.CreatePath(internalDatabasePath, projectPath)
+	if p.MultipleDrivers() {
+		err = p.CreateFileWithInjection(internalDatabasePath, projectPath, "database.go", "stores")
 		if err != nil {
-			log.Printf("Error creating path: %s", internalDatabasePath)
+			log.Printf("Error injecting database.go file: %v", err)
 			return err
 		}
+	}
 
-		err = p.CreateFileWithInjection(internalDatabasePath, projectPath, "database.go", "database")
+	if len(p.ORMStores()) > 0 {
+		err = p.CreateORMFiles(projectPath)
 		if err != nil {
-			log.Printf("Error injecting database.go file: %v", err)
+			log.Printf("Error creating the ORM files: %v", err)
 			return err
 		}
+	}
 
-		if p.DBDriver != "sqlite" {
-			err = p.CreateFileWithInjection(internalDatabasePath, projectPath, "database_test.go", "integration-tests")
-			if err != nil {
-				log.Printf("Error injecting database_test.go file: %v", err)
-				return err
-			}
+	if p.AdvancedOptions[flags.Migrations] {
+		err = p.CreateMigrationFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the migrations: %v", err)
+			return err
 		}
 	}
 
-	// Create correct docker compose for the selected driver
-	if p.DBDriver != "none" {
-		if p.DBDriver != "sqlite" {
-			p.createDockerMap()
-			p.Docker = p.DBDriver
+	if p.AdvancedOptions[flags.Sqlc] {
+		err = p.CreateQueriesFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the typed queries: %v", err)
+			return err
+		}
+	}
 
-			err = p.CreateFileWithInjection(root, projectPath, "docker-compose.yml", "db-docker")
-			if err != nil {
-				log.Printf("Error injecting docker-compose.yml file: %v", err)
-				return err
-			}
-		} else {
-			fmt.Println(" SQLite doesn't support docker-compose.yml configuration")
+	if p.AdvancedOptions[flags.Grpc] {
+		err = p.CreateGrpcFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the gRPC server: %v", err)
+			return err
 		}
 	}
 
-	// Install the godotenv package
-	err = utils.GoGetPackage(projectPath, godotenvPackage)
-	if err != nil {
-		log.Println("Could not install go dependency")
+	if p.AdvancedOptions[flags.Otel] {
+		err = p.CreateTelemetryFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the telemetry package: %v", err)
+			return err
+		}
+	}
 
-		return err
+	if p.AdvancedOptions[flags.Metrics] {
+		err = p.CreateMetricsFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the metrics package: %v", err)
+			return err
+		}
 	}
 
-	if p.DBDriver == flags.Scylla {
-		replace := fmt.Sprintf("%s=%s", gocqlDriver[0], scyllaDriver)
-		err = utils.GoModReplace(projectPath, replace)
+	// Create correct docker compose for the selected drivers, or the
+	// services of the features without the application, written with
+	// the Docker feature
+	if p.SupportsCompose() || (p.ComposesServices() && !p.AdvancedOptions[flags.Docker]) {
+		err = p.CreateFileWithInjection(root, projectPath, "docker-compose.yml", "db-docker")
 		if err != nil {
-			log.Printf("Could not replace go dependency %v\n", err)
+			log.Printf("Error injecting docker-compose.yml file: %v", err)
 			return err
 		}
 	}
+	if !p.SupportsCompose() && p.DBDriver != "none" && p.onDisk() {
+		for _, store := range p.Stores() {
+			fmt.Printf(" %s doesn't support docker-compose.yml configuration\n", store.Driver().Title)
+		}
+	}
 
-	err = p.CreatePath(cmdApiPath, projectPath)
+	// Install the godotenv package
+	err = p.goGetPackage(projectPath, godotenvPackage)
 	if err != nil {
-		log.Printf("Error creating path: %s", projectPath)
+		log.Println("Could not install go dependency")
+
 		return err
 	}
 
-	err = p.CreateFileWithInjection(cmdApiPath, projectPath, "main.go", "main")
-	if err != nil {
-		return err
+	for _, store := range p.Stores() {
+		for _, replace := range store.Driver().Replacements {
+			if p.onDisk() {
+				err = utils.GoModReplace(projectPath, replace)
+			} else {
+				err = p.appendGoModReplace(projectPath, replace)
+			}
+			if err != nil {
+				log.Printf("Could not replace go dependency %v\n", err)
+				return err
+			}
+		}
 	}
 
-	makeFile, err := os.Create(filepath.Join(projectPath, "Makefile"))
+	err = p.CreatePath(cmdApiPath, projectPath)
 	if err != nil {
+		log.Printf("Error creating path: %s", projectPath)
 		return err
 	}
 
-	defer makeFile.Close()
-
-	// inject makefile template
-	makeFileTemplate := template.Must(template.New("makefile").Parse(string(framework.MakeTemplate())))
-	err = makeFileTemplate.Execute(makeFile, p)
+	err = p.CreateFileWithInjection(cmdApiPath, projectPath, "main.go", "main")
 	if err != nil {
 		return err
 	}
 
-	readmeFile, err := os.Create(filepath.Join(projectPath, "README.md"))
+	// inject makefile template
+	err = p.renderFile(filepath.Join(projectPath, "Makefile"), framework.MakeTemplate())
 	if err != nil {
 		return err
 	}
-	defer readmeFile.Close()
 
 	// inject readme template
-	readmeFileTemplate := template.Must(template.New("readme").Parse(string(framework.ReadmeTemplate())))
-	err = readmeFileTemplate.Execute(readmeFile, p)
+	err = p.renderFile(filepath.Join(projectPath, "README.md"), framework.ReadmeTemplate())
 	if err != nil {
 		return err
 	}
@@ -422,36 +564,24 @@ func (p *Project) CreateMainFile() error {
 		// select htmx option automatically since tailwind is selected
 		p.AdvancedOptions[string(flags.Htmx)] = true
 
-		err = os.MkdirAll(fmt.Sprintf("%s/%s/assets/css", projectPath, cmdWebPath), 0o755)
+		err = p.FS.MkdirAll(fmt.Sprintf("%s/%s/assets/css", projectPath, cmdWebPath), 0o755)
 		if err != nil {
 			return err
 		}
 
-		err = os.MkdirAll(fmt.Sprintf("%s/%s/styles", projectPath, cmdWebPath), 0o755)
+		err = p.FS.MkdirAll(fmt.Sprintf("%s/%s/styles", projectPath, cmdWebPath), 0o755)
 		if err != nil {
 			return fmt.Errorf("failed to create styles directory: %w", err)
 		}
 
-		inputCssFile, err := os.Create(fmt.Sprintf("%s/%s/styles/input.css", projectPath, cmdWebPath))
-		if err != nil {
-			return err
-		}
-		defer inputCssFile.Close()
-
 		inputCssTemplate := advanced.InputCssTemplate()
-		err = os.WriteFile(fmt.Sprintf("%s/%s/styles/input.css", projectPath, cmdWebPath), inputCssTemplate, 0o644)
+		err = p.writeFile(fmt.Sprintf("%s/%s/styles/input.css", projectPath, cmdWebPath), inputCssTemplate)
 		if err != nil {
 			return err
 		}
 
-		outputCssFile, err := os.Create(fmt.Sprintf("%s/%s/assets/css/output.css", projectPath, cmdWebPath))
-		if err != nil {
-			return err
-		}
-		defer outputCssFile.Close()
-
 		outputCssTemplate := advanced.OutputCssTemplate()
-		err = os.WriteFile(fmt.Sprintf("%s/%s/assets/css/output.css", projectPath, cmdWebPath), outputCssTemplate, 0o644)
+		err = p.writeFile(fmt.Sprintf("%s/%s/assets/css/output.css", projectPath, cmdWebPath), outputCssTemplate)
 		if err != nil {
 			return err
 		}
@@ -463,97 +593,57 @@ func (p *Project) CreateMainFile() error {
 		if err != nil {
 			return err
 		}
-		helloTemplFile, err := os.Create(fmt.Sprintf("%s/%s/hello.templ", projectPath, cmdWebPath))
-		if err != nil {
-			return err
-		}
-		defer helloTemplFile.Close()
-
 		// inject hello.templ template
-		helloTemplTemplate := template.Must(template.New("hellotempl").Parse((string(advanced.HelloTemplTemplate()))))
-		err = helloTemplTemplate.Execute(helloTemplFile, p)
-		if err != nil {
-			return err
-		}
-
-		baseTemplFile, err := os.Create(fmt.Sprintf("%s/%s/base.templ", projectPath, cmdWebPath))
-		if err != nil {
-			return err
-		}
-		defer baseTemplFile.Close()
-
-		baseTemplTemplate := template.Must(template.New("basetempl").Parse((string(advanced.BaseTemplTemplate()))))
-		err = baseTemplTemplate.Execute(baseTemplFile, p)
+		err = p.renderFile(fmt.Sprintf("%s/%s/hello.templ", projectPath, cmdWebPath), advanced.HelloTemplTemplate())
 		if err != nil {
 			return err
 		}
 
-		err = os.MkdirAll(fmt.Sprintf("%s/%s/assets/js", projectPath, cmdWebPath), 0o755)
+		err = p.renderFile(fmt.Sprintf("%s/%s/base.templ", projectPath, cmdWebPath), advanced.BaseTemplTemplate())
 		if err != nil {
 			return err
 		}
 
-		htmxMinJsFile, err := os.Create(fmt.Sprintf("%s/%s/assets/js/htmx.min.js", projectPath, cmdWebPath))
+		err = p.FS.MkdirAll(fmt.Sprintf("%s/%s/assets/js", projectPath, cmdWebPath), 0o755)
 		if err != nil {
 			return err
 		}
-		defer htmxMinJsFile.Close()
 
 		htmxMinJsTemplate := advanced.HtmxJSTemplate()
-		err = os.WriteFile(fmt.Sprintf("%s/%s/assets/js/htmx.min.js", projectPath, cmdWebPath), htmxMinJsTemplate, 0o644)
-		if err != nil {
-			return err
-		}
-
-		htmxTailwindConfigJsFile, err := os.Create(fmt.Sprintf("%s/tailwind.config.js", projectPath))
+		err = p.writeFile(fmt.Sprintf("%s/%s/assets/js/htmx.min.js", projectPath, cmdWebPath), htmxMinJsTemplate)
 		if err != nil {
 			return err
 		}
-		defer htmxTailwindConfigJsFile.Close()
 
 		htmxTailwindConfigJsTemplate := advanced.HtmxTailwindConfigJsTemplate()
-		err = os.WriteFile(fmt.Sprintf("%s/tailwind.config.js", projectPath), htmxTailwindConfigJsTemplate, 0o644)
-		if err != nil {
-			return err
-		}
-
-		efsFile, err := os.Create(fmt.Sprintf("%s/%s/efs.go", projectPath, cmdWebPath))
+		err = p.writeFile(fmt.Sprintf("%s/tailwind.config.js", projectPath), htmxTailwindConfigJsTemplate)
 		if err != nil {
 			return err
 		}
-		defer efsFile.Close()
 
-		efsTemplate := template.Must(template.New("efs").Parse((string(advanced.EfsTemplate()))))
-		err = efsTemplate.Execute(efsFile, p)
+		err = p.renderFile(fmt.Sprintf("%s/%s/efs.go", projectPath, cmdWebPath), advanced.EfsTemplate())
 		if err != nil {
 			return err
 		}
-		err = utils.GoGetPackage(projectPath, templPackage)
+		err = p.goGetPackage(projectPath, templPackage)
 		if err != nil {
 			log.Println("Could not install go dependency")
 			return err
 		}
 
-		helloGoFile, err := os.Create(fmt.Sprintf("%s/%s/hello.go", projectPath, cmdWebPath))
-		if err != nil {
-			return err
-		}
-		defer efsFile.Close()
-
+		helloGoPath := fmt.Sprintf("%s/%s/hello.go", projectPath, cmdWebPath)
 		if p.ProjectType == "fiber" {
-			helloGoTemplate := template.Must(template.New("efs").Parse((string(advanced.HelloFiberGoTemplate()))))
-			err = helloGoTemplate.Execute(helloGoFile, p)
+			err = p.renderFile(helloGoPath, advanced.HelloFiberGoTemplate())
 			if err != nil {
 				return err
 			}
-			err = utils.GoGetPackage(projectPath, []string{"github.com/gofiber/fiber/v2/middleware/adaptor"})
+			err = p.goGetPackage(projectPath, []string{"github.com/gofiber/fiber/v2/middleware/adaptor"})
 			if err != nil {
 				log.Println("Could not install go dependency")
 				return err
 			}
 		} else {
-			helloGoTemplate := template.Must(template.New("efs").Parse((string(advanced.HelloGoTemplate()))))
-			err = helloGoTemplate.Execute(helloGoFile, p)
+			err = p.renderFile(helloGoPath, advanced.HelloGoTemplate())
 			if err != nil {
 				return err
 			}
@@ -598,36 +688,37 @@ func (p *Project) CreateMainFile() error {
 	}
 
 	if p.AdvancedOptions[string(flags.Docker)] {
-		Dockerfile, err := os.Create(filepath.Join(projectPath, "Dockerfile"))
-		if err != nil {
-			return err
-		}
-		defer Dockerfile.Close()
-
 		// inject Docker template
-		dockerfileTemplate := template.Must(template.New("Dockerfile").Parse(string(advanced.Dockerfile())))
-		err = dockerfileTemplate.Execute(Dockerfile, p)
+		err = p.renderFile(filepath.Join(projectPath, "Dockerfile"), advanced.Dockerfile())
 		if err != nil {
 			return err
 		}
 
-		if p.DBDriver == "none" || p.DBDriver == "sqlite" {
-
-			Dockercompose, err := os.Create(filepath.Join(projectPath, "docker-compose.yml"))
-			if err != nil {
-				return err
-			}
-			defer Dockercompose.Close()
-
+		if !p.SupportsCompose() {
 			// inject DockerCompose template
-			dockerComposeTemplate := template.Must(template.New("docker-compose.yml").Parse(string(advanced.DockerCompose())))
-			err = dockerComposeTemplate.Execute(Dockercompose, p)
+			err = p.CreateFileWithInjection(root, projectPath, "docker-compose.yml", "db-docker")
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	if p.AdvancedOptions[flags.Kubernetes] {
+		err = p.CreateKubernetesFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the Kubernetes manifests: %v", err)
+			return err
+		}
+	}
+
+	if p.AdvancedOptions[flags.Helm] {
+		err = p.CreateHelmFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the Helm chart: %v", err)
+			return err
+		}
+	}
+
 	err = p.CreateFileWithInjection(internalServerPath, projectPath, "routes.go", "routes")
 	if err != nil {
 		log.Printf("Error injecting routes.go file: %v", err)
@@ -639,79 +730,122 @@ func (p *Project) CreateMainFile() error {
 		return err
 	}
 
-	err = p.CreateFileWithInjection(internalServerPath, projectPath, "server.go", "server")
+	err = p.CreateLoggerFiles(projectPath)
 	if err != nil {
-		log.Printf("Error injecting server.go file: %v", err)
+		log.Printf("Error creating the logger package: %v", err)
 		return err
 	}
 
-	err = p.CreateFileWithInjection(root, projectPath, ".env", "env")
+	err = p.CreateConfigFiles(projectPath)
 	if err != nil {
-		log.Printf("Error injecting .env file: %v", err)
+		log.Printf("Error creating the config package: %v", err)
 		return err
 	}
 
-	// Create gitignore
-	gitignoreFile, err := os.Create(filepath.Join(projectPath, ".gitignore"))
+	err = p.CreateHealthFiles(projectPath)
 	if err != nil {
+		log.Printf("Error creating the health package: %v", err)
 		return err
 	}
-	defer gitignoreFile.Close()
 
-	// inject gitignore template
-	gitignoreTemplate := template.Must(template.New(".gitignore").Parse(string(framework.GitIgnoreTemplate())))
-	err = gitignoreTemplate.Execute(gitignoreFile, p)
+	if p.OpenAPI != nil {
+		err = p.CreateOpenAPIFiles(projectPath)
+		if err != nil {
+			log.Printf("Error generating the OpenAPI handlers: %v", err)
+			return err
+		}
+	}
+
+	if p.AdvancedOptions[flags.Graphql] {
+		err = p.CreateGraphqlFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the GraphQL server: %v", err)
+			return err
+		}
+	}
+
+	if p.AdvancedOptions[flags.Auth] {
+		err = p.CreateAuthFiles(projectPath)
+		if err != nil {
+			log.Printf("Error creating the auth package: %v", err)
+			return err
+		}
+	}
+
+	err = p.CreateFileWithInjection(internalServerPath, projectPath, "server.go", "server")
 	if err != nil {
+		log.Printf("Error injecting server.go file: %v", err)
 		return err
 	}
 
-	// Create .air.toml file
-	airTomlFile, err := os.Create(filepath.Join(projectPath, ".air.toml"))
+	err = p.CreateFileWithInjection(root, projectPath, ".env", "env")
 	if err != nil {
+		log.Printf("Error injecting .env file: %v", err)
 		return err
 	}
 
-	defer airTomlFile.Close()
+	// inject gitignore template
+	err = p.renderFile(filepath.Join(projectPath, ".gitignore"), framework.GitIgnoreTemplate())
+	if err != nil {
+		return err
+	}
 
 	// inject air.toml template
-	airTomlTemplate := template.Must(template.New("airtoml").Parse(string(framework.AirTomlTemplate())))
-	err = airTomlTemplate.Execute(airTomlFile, p)
+	err = p.renderFile(filepath.Join(projectPath, ".air.toml"), framework.AirTomlTemplate())
 	if err != nil {
 		return err
 	}
 
-	err = utils.GoTidy(projectPath)
+	err = p.runCommand("go mod tidy", func() error {
+		return utils.GoTidy(projectPath)
+	})
 	if err != nil {
 		log.Printf("Could not go tidy in new project %v\n", err)
 		return err
 	}
 
-	err = utils.GoFmt(projectPath)
+	if p.onDisk() {
+		err = utils.GoFmt(projectPath)
+	} else {
+		err = p.formatGoFiles()
+	}
 	if err != nil {
 		log.Printf("Could not gofmt in new project %v\n", err)
 		return err
 	}
 
+	err = p.writeManifest(projectPath, features)
+	if err != nil {
+		log.Printf("Could not write %s in new project %v\n", ManifestFile, err)
+		return err
+	}
+
 	if p.GitOptions != flags.Skip {
-		nameSet, err := utils.CheckGitConfig("user.name")
-		if err != nil {
-			return err
-		}
+		if p.onDisk() {
+			nameSet, err := utils.CheckGitConfig("user.name")
+			if err != nil {
+				return err
+			}
 
-		if !nameSet {
-			fmt.Println("user.name is not set in git config.")
-			fmt.Println("Please set up git config before trying again.")
-			panic("\nGIT CONFIG ISSUE: user.name is not set in git config.\n")
+			if !nameSet {
+				fmt.Println("user.name is not set in git config.")
+				fmt.Println("Please set up git config before trying again.")
+				panic("\nGIT CONFIG ISSUE: user.name is not set in git config.\n")
+			}
 		}
 		// Initialize git repo
-		err = utils.ExecuteCmd("git", []string{"init"}, projectPath)
+		err = p.runCommand("git init", func() error {
+			return utils.ExecuteCmd("git", []string{"init"}, projectPath)
+		})
 		if err != nil {
 			log.Printf("Error initializing git repo: %v", err)
 			return err
 		}
 
 		// Git add files
-		err = utils.ExecuteCmd("git", []string{"add", "."}, projectPath)
+		err = p.runCommand("git add .", func() error {
+			return utils.ExecuteCmd("git", []string{"add", "."}, projectPath)
+		})
 		if err != nil {
 			log.Printf("Error adding files to git repo: %v", err)
 			return err
@@ -719,7 +853,9 @@ func (p *Project) CreateMainFile() error {
 
 		if p.GitOptions == flags.Commit {
 			// Git commit files
-			err = utils.ExecuteCmd("git", []string{"commit", "-m", "Initial commit"}, projectPath)
+			err = p.runCommand("git commit -m \"Initial commit\"", func() error {
+				return utils.ExecuteCmd("git", []string{"commit", "-m", "Initial commit"}, projectPath)
+			})
 			if err != nil {
 				log.Printf("Error committing files to git repo: %v", err)
 				return err
@@ -732,12 +868,10 @@ func (p *Project) CreateMainFile() error {
 // CreatePath creates the given directory in the projectPath
 func (p *Project) CreatePath(pathToCreate string, projectPath string) error {
 	path := filepath.Join(projectPath, pathToCreate)
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		err := os.MkdirAll(path, 0o751)
-		if err != nil {
-			log.Printf("Error creating directory %v\n", err)
-			return err
-		}
+	err := p.FS.MkdirAll(path, 0o751)
+	if err != nil {
+		log.Printf("Error creating directory %v\n", err)
+		return err
 	}
 
 	return nil
@@ -746,116 +880,551 @@ func (p *Project) CreatePath(pathToCreate string, projectPath string) error {
 // CreateFileWithInjection creates the given file at the
 // project path, and injects the appropriate template
 func (p *Project) CreateFileWithInjection(pathToCreate string, projectPath string, fileName string, methodName string) error {
-	createdFile, err := os.Create(filepath.Join(projectPath, pathToCreate, fileName))
-	if err != nil {
-		return err
-	}
-
-	defer createdFile.Close()
+	var templateBytes []byte
 
 	switch methodName {
 	case "main":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.Main())))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = p.FrameworkMap[p.ProjectType].templater.Main()
 	case "server":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.Server())))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = p.FrameworkMap[p.ProjectType].templater.Server()
 	case "routes":
-		routeFileBytes := p.FrameworkMap[p.ProjectType].templater.Routes()
-		createdTemplate := template.Must(template.New(fileName).Parse(string(routeFileBytes)))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = p.FrameworkMap[p.ProjectType].templater.Routes()
 	case "releaser":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.Releaser())))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = advanced.Releaser()
 	case "go-test":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.Test())))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = advanced.Test()
 	case "releaser-config":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.ReleaserConfig())))
-		err = createdTemplate.Execute(createdFile, p)
-	case "database":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DBDriverMap[p.DBDriver].templater.Service())))
-		err = createdTemplate.Execute(createdFile, p)
-	case "db-docker":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DockerMap[p.Docker].templater.Docker())))
-		err = createdTemplate.Execute(createdFile, p)
-	case "integration-tests":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DBDriverMap[p.DBDriver].templater.Tests())))
-		err = createdTemplate.Execute(createdFile, p)
+		templateBytes = advanced.ReleaserConfig()
+	case "stores":
+		templateBytes = dbdriver.StoresTemplate()
 	case "tests":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.TestHandler())))
-		err = createdTemplate.Execute(createdFile, p)
-	case "env":
-		if p.DBDriver != "none" {
-
-			envBytes := [][]byte{
-				tpl.GlobalEnvTemplate(),
-				p.DBDriverMap[p.DBDriver].templater.Env(),
+		if p.OpenAPI != nil {
+			templateBytes = p.FrameworkMap[p.ProjectType].templater.(OpenAPITemplater).OpenAPITests()
+		} else {
+			templateBytes = p.FrameworkMap[p.ProjectType].templater.TestHandler()
+		}
+	case "openapi-handlers":
+		templateBytes = p.FrameworkMap[p.ProjectType].templater.(OpenAPITemplater).OpenAPIHandlers()
+	case "openapi-types":
+		templateBytes = framework.OpenAPITypesTemplate()
+	case "env", "db-docker":
+		// Both are made of the templates of every store,
+		// each rendered with its Store as data
+		var content []byte
+		var err error
+		if methodName == "env" {
+			content, err = p.envFile()
+		} else {
+			content, err = p.composeFile()
+		}
+		if err != nil {
+			return err
+		}
+		return p.writeFile(filepath.Join(projectPath, pathToCreate, fileName), content)
+	}
+
+	return p.renderFile(filepath.Join(projectPath, pathToCreate, fileName), templateBytes)
+}
+
+// CreateStoreFiles installs the driver packages of the store,
+// and writes its database service and integration tests
+func (p *Project) CreateStoreFiles(store Store, projectPath string) error {
+	driver := p.DBDriverMap[store.DBDriver]
+	err := p.goGetPackage(projectPath, driver.packageName)
+	if err != nil {
+		log.Println("Could not install go dependency for chosen driver")
+		return err
+	}
+
+	serviceBlocks := [][]byte{orm.BlocksTemplate()}
+	testsBlocks := [][]byte{orm.BlocksTemplate()}
+	if o, ok := store.orm(); ok {
+		err = p.goGetPackage(projectPath, o.Packages[store.DBDriver])
+		if err != nil {
+			log.Println("Could not install go dependency for chosen ORM")
+			return err
+		}
+		serviceBlocks = append(serviceBlocks, o.Templater.Service())
+		testsBlocks = append(testsBlocks, o.Templater.Tests())
+	}
+
+	err = p.CreatePath(store.Path(), projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", store.Path())
+		return err
+	}
+
+	err = p.render(filepath.Join(projectPath, store.Path(), "database.go"), driver.templater.Service(), store, serviceBlocks...)
+	if err != nil {
+		log.Printf("Error injecting database.go file: %v", err)
+		return err
+	}
+
+	if tests := driver.templater.Tests(); len(tests) > 0 {
+		err = p.render(filepath.Join(projectPath, store.Path(), "database_test.go"), tests, store, testsBlocks...)
+		if err != nil {
+			log.Printf("Error injecting database_test.go file: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateORMFiles writes the example schema of an ORM generating its
+// client from it, and generates the client
+func (p *Project) CreateORMFiles(projectPath string) error {
+	o, _ := p.ORMStores()[0].orm()
+	codegen, ok := o.Templater.(ORMCodegenTemplater)
+	if !ok {
+		return nil
+	}
+
+	ormPath := string(o.Name)
+	schemaDir := filepath.Join(ormPath, "schema")
+	err := p.CreatePath(schemaDir, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", schemaDir)
+		return err
+	}
+	err = p.renderFile(filepath.Join(projectPath, schemaDir, "example.go"), codegen.Schema())
+	if err != nil {
+		return err
+	}
+	err = p.renderFile(filepath.Join(projectPath, ormPath, "generate.go"), codegen.Generate())
+	if err != nil {
+		return err
+	}
+	err = p.renderFile(filepath.Join(projectPath, ormPath, "tools.go"), codegen.Tools())
+	if err != nil {
+		return err
+	}
+
+	return p.runCommand("go generate ./"+ormPath, func() error {
+		return utils.ExecuteCmd("go", []string{"generate", "./" + ormPath}, projectPath)
+	})
+}
+
+// CreateMigrationFiles writes the migration runner of every SQL store,
+// the migrations directory with a first migration for each of them,
+// and the command applying the migrations
+func (p *Project) CreateMigrationFiles(projectPath string) error {
+	err := p.goGetPackage(projectPath, migratePackage)
+	if err != nil {
+		log.Println("Could not install go dependency for the migrations")
+		return err
+	}
+
+	for _, store := range p.MigratedStores() {
+		templater := p.DBDriverMap[store.DBDriver].templater.(MigrationsTemplater)
+		err = p.render(filepath.Join(projectPath, store.Path(), "migrate.go"), templater.Migrate(), store)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(projectPath, migrationsPath, store.Dir())
+		err = p.FS.MkdirAll(dir, 0o751)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(dir, "000001_init.up.sql"), dbdriver.InitUpMigrationTemplate(), store)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(dir, "000001_init.down.sql"), dbdriver.InitDownMigrationTemplate(), store)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.renderFile(filepath.Join(projectPath, migrationsPath, "migrations.go"), dbdriver.MigrationsTemplate())
+	if err != nil {
+		return err
+	}
+
+	err = p.CreatePath(cmdMigratePath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", cmdMigratePath)
+		return err
+	}
+	return p.renderFile(filepath.Join(projectPath, cmdMigratePath, "main.go"), dbdriver.MigrateMainTemplate())
+}
+
+// CreateQueriesFiles writes sqlc.yaml, the example queries of every
+// SQL store and the code sqlc generates from them, along with the
+// schema of the stores when the migrations do not hold it
+func (p *Project) CreateQueriesFiles(projectPath string) error {
+	for _, store := range p.QueriedStores() {
+		templater := p.DBDriverMap[store.DBDriver].templater.(QueriesTemplater)
+
+		if !p.AdvancedOptions[flags.Migrations] {
+			err := p.FS.MkdirAll(filepath.Join(projectPath, store.SchemaPath()), 0o751)
+			if err != nil {
+				return err
+			}
+			err = p.render(filepath.Join(projectPath, store.SchemaPath(), "schema.sql"), dbdriver.InitUpMigrationTemplate(), store)
+			if err != nil {
+				return err
 			}
-			createdTemplate := template.Must(template.New(fileName).Parse(string(bytes.Join(envBytes, []byte("\n")))))
-			err = createdTemplate.Execute(createdFile, p)
+		}
 
-		} else {
-			createdTemplate := template.Must(template.New(fileName).Parse(string(tpl.GlobalEnvTemplate())))
-			err = createdTemplate.Execute(createdFile, p)
+		err := p.FS.MkdirAll(filepath.Join(projectPath, store.QueriesPath()), 0o751)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(projectPath, store.QueriesPath(), "examples.sql"), templater.Queries(), store)
+		if err != nil {
+			return err
+		}
+
+		out := filepath.Join(projectPath, store.Path(), "queries")
+		err = p.FS.MkdirAll(out, 0o751)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(out, "db.go"), dbdriver.QueriesDBTemplate(), store)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(out, "models.go"), templater.QueriesModels(), store)
+		if err != nil {
+			return err
+		}
+		err = p.render(filepath.Join(out, "examples.sql.go"), templater.QueriesCode(), store)
+		if err != nil {
+			return err
 		}
 	}
 
+	sqlc, err := p.sqlcFile()
+	if err != nil {
+		return err
+	}
+	return p.writeFile(filepath.Join(projectPath, "sqlc.yaml"), sqlc)
+}
+
+// CreateGrpcFiles writes the proto directory with a sample service,
+// the buf configuration generating its stubs, the stubs themselves
+// and the gRPC server of internal/grpc
+func (p *Project) CreateGrpcFiles(projectPath string) error {
+	err := p.goGetPackage(projectPath, grpcPackage)
 	if err != nil {
 		return err
 	}
 
+	protoDir := filepath.Join(projectPath, protoPath, "hello", "v1")
+	genDir := filepath.Join(projectPath, internalGrpcPath, "gen", "hello", "v1")
+	for _, dir := range []string{protoDir, genDir} {
+		err = p.FS.MkdirAll(dir, 0o751)
+		if err != nil {
+			return err
+		}
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, "buf.yaml"), advanced.Buf()},
+		{filepath.Join(projectPath, "buf.gen.yaml"), advanced.BufGen()},
+		{filepath.Join(protoDir, "hello.proto"), advanced.GrpcProto()},
+		{filepath.Join(genDir, "hello.pb.go"), advanced.GrpcMessages()},
+		{filepath.Join(genDir, "hello_grpc.pb.go"), advanced.GrpcStubs()},
+		{filepath.Join(projectPath, internalGrpcPath, "server.go"), advanced.GrpcServer()},
+		{filepath.Join(projectPath, internalGrpcPath, "hello.go"), advanced.GrpcHello()},
+		{filepath.Join(projectPath, internalGrpcPath, "health.go"), advanced.GrpcHealth()},
+		{filepath.Join(projectPath, internalGrpcPath, "server_test.go"), advanced.GrpcServerTest()},
+	}
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (p *Project) CreateViteReactProject(projectPath string) error {
-	if err := checkNpmInstalled(); err != nil {
+// CreateGraphqlFiles writes gqlgen.yml, the starter schema of
+// internal/graph with its resolvers and the handlers serving them,
+// and generates the GraphQL server
+func (p *Project) CreateGraphqlFiles(projectPath string) error {
+	err := p.goGetPackage(projectPath, gqlgenPackage)
+	if err != nil {
+		return err
+	}
+
+	err = p.CreatePath(internalGraphPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalGraphPath)
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, "gqlgen.yml"), advanced.Gqlgen()},
+		{filepath.Join(projectPath, internalGraphPath, "schema.graphqls"), advanced.GraphqlSchema()},
+		{filepath.Join(projectPath, internalGraphPath, "resolver.go"), advanced.GraphqlResolver()},
+		{filepath.Join(projectPath, internalGraphPath, "schema.resolvers.go"), advanced.GraphqlSchemaResolvers()},
+		{filepath.Join(projectPath, internalGraphPath, "tools.go"), advanced.GraphqlTools()},
+		{filepath.Join(projectPath, internalServerPath, "graphql.go"), advanced.GraphqlServer()},
+		{filepath.Join(projectPath, internalServerPath, "graphql_test.go"), advanced.GraphqlServerTest()},
+	}
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
+		}
+	}
+	if p.DBDriver != "none" {
+		err = p.renderFile(filepath.Join(projectPath, internalGraphPath, "health.go"), advanced.GraphqlHealth())
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.runCommand("go generate ./"+internalGraphPath, func() error {
+		return utils.ExecuteCmd("go", []string{"generate", "./" + internalGraphPath}, projectPath)
+	})
+}
+
+// CreateTelemetryFiles writes the telemetry package setting up
+// OpenTelemetry, and the configuration of the collector receiving
+// the telemetry in docker-compose.yml. The packages instrumenting
+// the framework and the database clients are installed along
+func (p *Project) CreateTelemetryFiles(projectPath string) error {
+	packages := slices.Clone(otelPackages)
+	if middleware, ok := otelHTTPPackages[p.ProjectType]; ok {
+		packages = append(packages, middleware...)
+	} else {
+		packages = append(packages, otelhttpPackage...)
+	}
+	for _, store := range p.Stores() {
+		for _, pkg := range store.Driver().TelemetryPackages {
+			if !slices.Contains(packages, pkg) {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	err := p.goGetPackage(projectPath, packages)
+	if err != nil {
 		return err
 	}
 
-	originalDir, err := os.Getwd()
+	err = p.CreatePath(internalTelemetryPath, projectPath)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		log.Printf("Error creating path: %s", internalTelemetryPath)
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, internalTelemetryPath, "telemetry.go"), advanced.Telemetry()},
+		{filepath.Join(projectPath, internalTelemetryPath, "telemetry_test.go"), advanced.TelemetryTest()},
+		{filepath.Join(projectPath, "otel-collector.yaml"), advanced.OtelCollector()},
 	}
-	defer func() {
-		if err := os.Chdir(originalDir); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to change back to original directory: %v\n", err)
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
 		}
-	}()
+	}
+	return nil
+}
 
-	// change into the project directory to run vite command
-	err = os.Chdir(projectPath)
+// CreateLoggerFiles writes the logger package setting up log/slog,
+// and the middleware of the framework logging the requests
+func (p *Project) CreateLoggerFiles(projectPath string) error {
+	err := p.CreatePath(internalLoggerPath, projectPath)
 	if err != nil {
-		fmt.Println("failed to change into project directory: %w", err)
+		log.Printf("Error creating path: %s", internalLoggerPath)
+		return err
 	}
 
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, internalLoggerPath, "logger.go"), framework.LoggerTemplate()},
+		{filepath.Join(projectPath, internalLoggerPath, "logger_test.go"), framework.LoggerTestTemplate()},
+		{filepath.Join(projectPath, internalLoggerPath, "middleware.go"), p.FrameworkMap[p.ProjectType].templater.LoggerMiddleware()},
+	}
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateConfigFiles writes the config package loading the settings
+// of the application and of its stores from the environment
+func (p *Project) CreateConfigFiles(projectPath string) error {
+	err := p.CreatePath(internalConfigPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalConfigPath)
+		return err
+	}
+
+	config, err := p.configFile()
+	if err != nil {
+		return err
+	}
+	err = p.writeFile(filepath.Join(projectPath, internalConfigPath, "config.go"), config)
+	if err != nil {
+		return err
+	}
+	return p.renderFile(filepath.Join(projectPath, internalConfigPath, "config_test.go"), framework.ConfigTestTemplate())
+}
+
+// CreateHealthFiles writes the health package answering the liveness
+// and readiness probes
+func (p *Project) CreateHealthFiles(projectPath string) error {
+	err := p.CreatePath(internalHealthPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalHealthPath)
+		return err
+	}
+
+	err = p.renderFile(filepath.Join(projectPath, internalHealthPath, "health.go"), framework.HealthTemplate())
+	if err != nil {
+		return err
+	}
+	return p.renderFile(filepath.Join(projectPath, internalHealthPath, "health_test.go"), framework.HealthTestTemplate())
+}
+
+// CreateMetricsFiles writes the metrics package serving the Prometheus
+// metrics and the pprof diagnostics, and the configuration of the
+// Prometheus server of docker-compose.yml
+func (p *Project) CreateMetricsFiles(projectPath string) error {
+	err := p.goGetPackage(projectPath, metricsPackage)
+	if err != nil {
+		return err
+	}
+
+	err = p.CreatePath(internalMetricsPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalMetricsPath)
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template []byte
+	}{
+		{filepath.Join(projectPath, internalMetricsPath, "metrics.go"), advanced.Metrics()},
+		{filepath.Join(projectPath, internalMetricsPath, "admin.go"), advanced.MetricsAdmin()},
+		{filepath.Join(projectPath, internalMetricsPath, "metrics_test.go"), advanced.MetricsTest()},
+		{filepath.Join(projectPath, "prometheus.yml"), advanced.Prometheus()},
+	}
+	for _, file := range files {
+		err = p.renderFile(file.name, file.template)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ComposesServices reports whether a feature runs services of its
+// own in docker-compose.yml, like the collector of the otel feature
+func (p *Project) ComposesServices() bool {
+	return p.AdvancedOptions[flags.Otel] || p.AdvancedOptions[flags.Metrics]
+}
+
+// CORSOrigins returns the origins the routes of the framework allow
+// cross-origin requests from when CORS_ALLOWED_ORIGINS is not set,
+// comma separated like the variable
+func (p *Project) CORSOrigins() string {
+	switch p.ProjectType {
+	case flags.Chi, flags.Echo:
+		return "https://*,http://*"
+	case flags.Gin:
+		return "http://localhost:5173"
+	}
+	return "*"
+}
+
+// ServiceName returns the name the project reports its
+// telemetry under, the last element of its module path
+func (p *Project) ServiceName() string {
+	return utils.GetRootDir(p.ProjectName)
+}
+
+// CreateOpenAPIFiles writes the types and handlers generated from the
+// OpenAPI document of the project, and a copy of the document
+func (p *Project) CreateOpenAPIFiles(projectPath string) error {
+	err := p.CreatePath(internalApiPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", internalApiPath)
+		return err
+	}
+
+	err = p.CreateFileWithInjection(internalApiPath, projectPath, "types.go", "openapi-types")
+	if err != nil {
+		log.Printf("Error injecting types.go file: %v", err)
+		return err
+	}
+
+	err = p.CreateFileWithInjection(internalServerPath, projectPath, "handlers.go", "openapi-handlers")
+	if err != nil {
+		log.Printf("Error injecting handlers.go file: %v", err)
+		return err
+	}
+
+	err = p.CreatePath(apiPath, projectPath)
+	if err != nil {
+		log.Printf("Error creating path: %s", apiPath)
+		return err
+	}
+
+	return p.writeFile(filepath.Join(projectPath, p.openAPIPath()), p.OpenAPI.Source)
+}
+
+// openAPIPath returns the path of the copy of the OpenAPI
+// document, relative to the project directory
+func (p *Project) openAPIPath() string {
+	return apiPath + "/openapi" + p.OpenAPI.Ext
+}
+
+func (p *Project) CreateViteReactProject(projectPath string) error {
 	// the interactive vite command will not work as we can't interact with it
-	fmt.Println("Installing create-vite (using cache if available)...")
-	cmd := exec.Command("npm", "create", "vite@latest", "frontend", "--",
-		"--template", "react-ts",
-		"--prefer-offline",
-		"--no-fund")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to use create-vite: %w", err)
+	err := p.runCommand("npm create vite@latest frontend -- --template react-ts", func() error {
+		if err := checkNpmInstalled(); err != nil {
+			return err
+		}
+
+		fmt.Println("Installing create-vite (using cache if available)...")
+		cmd := exec.Command("npm", "create", "vite@latest", "frontend", "--",
+			"--template", "react-ts",
+			"--prefer-offline",
+			"--no-fund")
+		cmd.Dir = projectPath
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to use create-vite: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	frontendPath := filepath.Join(projectPath, "frontend")
-	if err := os.MkdirAll(frontendPath, 0755); err != nil {
+	if err := p.FS.MkdirAll(frontendPath, 0755); err != nil {
 		return fmt.Errorf("failed to create frontend directory: %w", err)
 	}
 
-	if err := os.Chdir(frontendPath); err != nil {
-		return fmt.Errorf("failed to change to frontend directory: %w", err)
-	}
-
 	srcDir := filepath.Join(frontendPath, "src")
-	if err := os.MkdirAll(srcDir, 0755); err != nil {
+	if err := p.FS.MkdirAll(srcDir, 0755); err != nil {
 		return fmt.Errorf("failed to create src directory: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Join(srcDir, "App.tsx"), advanced.ReactAppfile(), 0644); err != nil {
+	if err := p.writeFile(filepath.Join(srcDir, "App.tsx"), advanced.ReactAppfile()); err != nil {
 		return fmt.Errorf("failed to write App.tsx template: %w", err)
 	}
 
@@ -870,7 +1439,7 @@ func (p *Project) CreateViteReactProject(projectPath string) error {
 	vitePort := "8080" // Default fallback
 
 	// Read the global .env file
-	if data, err := os.ReadFile(globalEnvPath); err == nil {
+	if data, err := p.FS.ReadFile(globalEnvPath); err == nil {
 		lines := strings.Split(string(data), "\n")
 		for _, line := range lines {
 			if strings.HasPrefix(line, "PORT=") {
@@ -882,43 +1451,45 @@ func (p *Project) CreateViteReactProject(projectPath string) error {
 
 	// Use a template to generate the frontend .env file
 	frontendEnvContent := fmt.Sprintf("VITE_PORT=%s\n", vitePort)
-	if err := os.WriteFile(filepath.Join(frontendPath, ".env"), []byte(frontendEnvContent), 0644); err != nil {
+	if err := p.writeFile(filepath.Join(frontendPath, ".env"), []byte(frontendEnvContent)); err != nil {
 		return fmt.Errorf("failed to create frontend .env file: %w", err)
 	}
 
 	// Handle Tailwind configuration if selected
 	if p.AdvancedOptions[string(flags.Tailwind)] {
-		fmt.Println("Installing Tailwind dependencies (using cache if available)...")
-		cmd := exec.Command("npm", "install",
-			"--prefer-offline",
-			"--no-fund",
-			"tailwindcss@^4", "@tailwindcss/vite")
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to install Tailwind: %w", err)
+		err := p.runCommand("npm install tailwindcss@^4 @tailwindcss/vite", func() error {
+			fmt.Println("Installing Tailwind dependencies (using cache if available)...")
+			cmd := exec.Command("npm", "install",
+				"--prefer-offline",
+				"--no-fund",
+				"tailwindcss@^4", "@tailwindcss/vite")
+			cmd.Dir = frontendPath
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("failed to install Tailwind: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 
 		// Create the vite + react + Tailwind v4 configuration
-		if err := os.WriteFile(filepath.Join(frontendPath, "vite.config.ts"), advanced.ViteTailwindConfigFile(), 0644); err != nil {
+		if err := p.writeFile(filepath.Join(frontendPath, "vite.config.ts"), advanced.ViteTailwindConfigFile()); err != nil {
 			return fmt.Errorf("failed to write vite.config.ts: %w", err)
 		}
 
-		srcDir := filepath.Join(frontendPath, "src")
-		if err := os.MkdirAll(srcDir, 0755); err != nil {
-			return fmt.Errorf("failed to create src directory: %w", err)
-		}
-
-		err = os.WriteFile(filepath.Join(srcDir, "index.css"), advanced.InputCssTemplateReact(), 0644)
+		err = p.writeFile(filepath.Join(srcDir, "index.css"), advanced.InputCssTemplateReact())
 		if err != nil {
 			return fmt.Errorf("failed to update index.css: %w", err)
 		}
 
-		if err := os.WriteFile(filepath.Join(srcDir, "App.tsx"), advanced.ReactTailwindAppfile(), 0644); err != nil {
+		if err := p.writeFile(filepath.Join(srcDir, "App.tsx"), advanced.ReactTailwindAppfile()); err != nil {
 			return fmt.Errorf("failed to write App.tsx template: %w", err)
 		}
 
-		if err := os.Remove(filepath.Join(srcDir, "App.css")); err != nil {
+		if err := p.removeFile(filepath.Join(srcDir, "App.css")); err != nil {
 			// Don't return error if file doesn't exist
 			if !os.IsNotExist(err) {
 				return fmt.Errorf("failed to remove App.css: %w", err)
@@ -971,7 +1542,7 @@ func (p *Project) CreateWebsocketImports(appDir string) {
 	// Websockets require a different package depending on what framework is
 	// choosen. The application calls go mod tidy at the end so we don't
 	// have to here
-	err := utils.GoGetPackage(appDir, websocketDependency)
+	err := p.goGetPackage(appDir, websocketDependency)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -998,3 +1569,95 @@ func checkNpmInstalled() error {
 	}
 	return nil
 }
+
+// onDisk reports whether the project is written to the operating
+// system, where external commands can operate on it
+func (p *Project) onDisk() bool {
+	return onDisk(p.FS)
+}
+
+// onDisk reports whether fsys is the operating system
+func onDisk(fsys filesystem.FileSystem) bool {
+	_, ok := fsys.(filesystem.OS)
+	return ok
+}
+
+// runCommand runs step, an external command described by description.
+// It is only recorded when the project is not written to disk
+func (p *Project) runCommand(description string, step func() error) error {
+	if !p.onDisk() {
+		p.SkippedCommands = append(p.SkippedCommands, description)
+		return nil
+	}
+
+	return step()
+}
+
+// goGetPackage runs "go get" for the given packages in the
+// project directory
+func (p *Project) goGetPackage(projectPath string, packages []string) error {
+	return p.runCommand("go get -u "+strings.Join(packages, " "), func() error {
+		return utils.GoGetPackage(projectPath, packages)
+	})
+}
+
+// writeFile writes data to the named file through the Project
+// file system, and keeps track of it as a generated file
+func (p *Project) writeFile(name string, data []byte) error {
+	if err := p.FS.WriteFile(name, data, 0o644); err != nil {
+		return err
+	}
+
+	if !slices.Contains(p.files, name) {
+		p.files = append(p.files, name)
+	}
+	return nil
+}
+
+// removeFile removes a file previously written for the project
+func (p *Project) removeFile(name string) error {
+	if err := p.FS.Remove(name); err != nil {
+		return err
+	}
+
+	p.files = slices.DeleteFunc(p.files, func(file string) bool { return file == name })
+	return nil
+}
+
+// renderFile executes the given template with the Project
+// as its data and writes the result to the named file
+func (p *Project) renderFile(name string, templateBytes []byte) error {
+	return p.render(name, templateBytes, p)
+}
+
+// render executes the given template with data, along with the
+// blocks it uses, and writes the result to the named file
+func (p *Project) render(name string, templateBytes []byte, data any, blocks ...[]byte) error {
+	content, err := execute(filepath.Base(name), templateBytes, data, blocks...)
+	if err != nil {
+		return err
+	}
+
+	return p.writeFile(name, content)
+}
+
+// execute executes the named template with data. blocks are
+// templates defining the blocks it uses, a later definition of
+// a block replacing the earlier ones
+func execute(name string, templateBytes []byte, data any, blocks ...[]byte) ([]byte, error) {
+	createdTemplate, err := template.New(name).Parse(string(templateBytes))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the template of %s: %w", name, err)
+	}
+	for _, block := range blocks {
+		if _, err := createdTemplate.Parse(string(block)); err != nil {
+			return nil, fmt.Errorf("could not parse the blocks of %s: %w", name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := createdTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,580 @@
+package program
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/openapi"
+)
+
+// newTestProject returns a Project generated into memory, with the
+// same maps the create command sets up
+func newTestProject(framework flags.Framework, driver flags.Database, features ...string) (*Project, *filesystem.Memory) {
+	memory := filesystem.NewMemory()
+	project := &Project{
+		ProjectName:     "github.com/user/blueprint",
+		AbsolutePath:    "/workspace",
+		ProjectType:     framework,
+		DBDriver:        driver,
+		FrameworkMap:    make(map[flags.Framework]Framework),
+		DBDriverMap:     make(map[flags.Database]Driver),
+		AdvancedOptions: make(map[string]bool),
+		GitOptions:      flags.Skip,
+		FS:              memory,
+	}
+	for _, feature := range features {
+		project.AdvancedOptions[feature] = true
+	}
+
+	return project, memory
+}
+
+// assertGoFilesParse fails the test for every generated Go
+// file that is not syntactically valid
+func assertGoFilesParse(t *testing.T, memory *filesystem.Memory) {
+	t.Helper()
+
+	for _, path := range memory.Paths() {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
+		content, err := memory.ReadFile(path)
+		if err != nil {
+			t.Fatalf("could not read %s: %v", path, err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), path, content, parser.AllErrors); err != nil {
+			t.Errorf("generated file %s does not parse: %v", path, err)
+		}
+	}
+}
+
+// assertRendered fails the test for every generated file, relative to
+// the project, that is missing or lacks one of its expected substrings
+func assertRendered(t *testing.T, memory *filesystem.Memory, expected map[string][]string) {
+	t.Helper()
+
+	for name, wants := range expected {
+		content, err := memory.ReadFile(filepath.Join("/workspace/blueprint", name))
+		if err != nil {
+			t.Errorf("expected %s: %v", name, err)
+			continue
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q:\n%s", name, want, content)
+			}
+		}
+	}
+}
+
+func TestCreateMainFileInMemory(t *testing.T) {
+	for _, framework := range flags.AllowedProjectTypes {
+		for _, driver := range flags.AllowedDBDrivers {
+			t.Run(framework+"/"+driver, func(t *testing.T) {
+				t.Parallel()
+
+				project, memory := newTestProject(flags.Framework(framework), flags.Database(driver))
+				if err := project.CreateMainFile(); err != nil {
+					t.Fatalf("could not create project: %v", err)
+				}
+
+				for _, expected := range []string{"go.mod", "cmd/api/main.go", "internal/server/routes.go", "internal/server/server.go", "Makefile", ".env"} {
+					if _, err := memory.Stat(filepath.Join("/workspace/blueprint", expected)); err != nil {
+						t.Errorf("expected %s to be generated: %v", expected, err)
+					}
+				}
+				assertGoFilesParse(t, memory)
+
+				if !slices.Contains(project.SkippedCommands, "go mod tidy") {
+					t.Errorf("expected go mod tidy to be skipped, got %v", project.SkippedCommands)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateMainFileAdvancedInMemory(t *testing.T) {
+	for _, framework := range flags.AllowedProjectTypes {
+		t.Run(framework, func(t *testing.T) {
+			t.Parallel()
+
+			project, memory := newTestProject(flags.Framework(framework), flags.Postgres,
+				flags.Htmx, flags.Tailwind, flags.Websocket, flags.Docker, flags.GoProjectWorkflow)
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+
+			for _, expected := range []string{"cmd/web/hello.templ", "cmd/web/styles/input.css", "Dockerfile", ".github/workflows/release.yml"} {
+				if _, err := memory.Stat(filepath.Join("/workspace/blueprint", expected)); err != nil {
+					t.Errorf("expected %s to be generated: %v", expected, err)
+				}
+			}
+			assertGoFilesParse(t, memory)
+		})
+	}
+}
+
+func TestOfflineGoMod(t *testing.T) {
+	project, memory := newTestProject(flags.Chi, flags.Scylla)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project: %v", err)
+	}
+
+	goMod, err := memory.ReadFile("/workspace/blueprint/go.mod")
+	if err != nil {
+		t.Fatalf("expected go.mod to be generated: %v", err)
+	}
+	if !strings.HasPrefix(string(goMod), "module github.com/user/blueprint\n\ngo ") {
+		t.Errorf("unexpected go.mod header:\n%s", goMod)
+	}
+	if !strings.Contains(string(goMod), "replace github.com/gocql/gocql => github.com/scylladb/gocql v1.14.4") {
+		t.Errorf("expected the scylla replace directive in go.mod:\n%s", goMod)
+	}
+
+	main, err := memory.ReadFile("/workspace/blueprint/internal/server/server.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if formatted, _ := format.Source(main); string(formatted) != string(main) {
+		t.Errorf("expected generated Go files to be gofmt'ed")
+	}
+}
+
+func TestManifest(t *testing.T) {
+	project, memory := newTestProject(flags.Gin, flags.Mongo, flags.Docker, flags.Websocket)
+	project.GitOptions = flags.Commit
+	project.BlueprintVersion = "v1.2.3"
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project: %v", err)
+	}
+
+	manifest, err := ReadManifest(memory, "/workspace/blueprint")
+	if err != nil {
+		t.Fatalf("could not read manifest: %v", err)
+	}
+	if manifest.Version != "v1.2.3" || manifest.Name != "github.com/user/blueprint" || manifest.Framework != flags.Gin || manifest.Driver != flags.Mongo || manifest.Git != flags.Commit {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+	if !slices.Equal(manifest.Features, []string{flags.Docker, flags.Websocket}) {
+		t.Errorf("unexpected manifest features %v", manifest.Features)
+	}
+
+	routes, err := memory.ReadFile("/workspace/blueprint/internal/server/routes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !manifest.Unmodified("internal/server/routes.go", routes) {
+		t.Errorf("expected the manifest to hold the checksum of routes.go")
+	}
+	if manifest.Unmodified("internal/server/routes.go", append(routes, '\n')) {
+		t.Errorf("expected a changed routes.go not to match its checksum")
+	}
+	if _, ok := manifest.Files["go.mod"]; ok {
+		t.Errorf("did not expect go.mod in the manifest")
+	}
+}
+
+func TestCreateMainFileOpenAPI(t *testing.T) {
+	spec, err := openapi.Load("../openapi/testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, framework := range flags.AllowedProjectTypes {
+		t.Run(framework, func(t *testing.T) {
+			t.Parallel()
+
+			project, memory := newTestProject(flags.Framework(framework), flags.None)
+			project.OpenAPI = spec
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+
+			for _, expected := range []string{"internal/api/types.go", "internal/server/handlers.go", "api/openapi.yaml"} {
+				if _, err := memory.Stat(filepath.Join("/workspace/blueprint", expected)); err != nil {
+					t.Errorf("expected %s to be generated: %v", expected, err)
+				}
+			}
+			assertGoFilesParse(t, memory)
+
+			routes, err := memory.ReadFile("/workspace/blueprint/internal/server/routes.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(routes), "HelloWorldHandler") || !strings.Contains(string(routes), "s.GetPetsByPetID") {
+				t.Errorf("expected the routes of the OpenAPI document in place of the hello world handler:\n%s", routes)
+			}
+
+			tests, err := memory.ReadFile("/workspace/blueprint/internal/server/routes_test.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, operation := range spec.Operations {
+				if !strings.Contains(string(tests), `"`+operation.Handler+`"`) {
+					t.Errorf("expected a test case for %s", operation.Handler)
+				}
+			}
+
+			manifest, err := ReadManifest(memory, "/workspace/blueprint")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.OpenAPI != "api/openapi.yaml" {
+				t.Errorf("expected the manifest to record the OpenAPI document, got %q", manifest.OpenAPI)
+			}
+		})
+	}
+}
+
+func TestCreateMainFileMultipleDrivers(t *testing.T) {
+	project, memory := newTestProject(flags.Chi, "postgres,redis,sqlite", flags.Docker)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project: %v", err)
+	}
+	assertGoFilesParse(t, memory)
+
+	read := func(name string) string {
+		t.Helper()
+		content, err := memory.ReadFile(filepath.Join("/workspace/blueprint", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	for _, store := range []string{"postgres", "redis", "sqlite"} {
+		service := read("internal/database/" + store + "/database.go")
+		goName := strings.ToUpper(store[:1]) + store[1:]
+		if !strings.HasPrefix(service, "package "+store+"\n") || !strings.Contains(service, "func New(cfg config."+goName+") Service {") {
+			t.Errorf("expected the %s store in its own package, with its own settings:\n%s", store, service)
+		}
+	}
+
+	config := read("internal/config/config.go")
+	for _, expected := range []string{"func loadPostgres(l *loader) Postgres {", `l.string("BLUEPRINT_POSTGRES_HOST", "")`, `l.string("BLUEPRINT_REDIS_ADDRESS", "")`, `l.string("BLUEPRINT_SQLITE_URL", "")`} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("expected the config package to contain %s:\n%s", expected, config)
+		}
+	}
+	if _, err := memory.Stat("/workspace/blueprint/internal/database/redis/database_test.go"); err != nil {
+		t.Errorf("expected the integration tests of the redis store: %v", err)
+	}
+
+	database := read("internal/database/database.go")
+	for _, expected := range []string{`"github.com/user/blueprint/internal/database/postgres"`, `"redis":    s.redis.Health()`, "Sqlite() sqlite.Service", "sqlite:   sqlite.New(cfg.Sqlite),"} {
+		if !strings.Contains(database, expected) {
+			t.Errorf("expected the database package to contain %s:\n%s", expected, database)
+		}
+	}
+
+	env := read(".env")
+	for _, expected := range []string{"PORT=8080", "# Postgres\nBLUEPRINT_POSTGRES_HOST=psql_bp", "BLUEPRINT_REDIS_ADDRESS=redis_bp", "BLUEPRINT_SQLITE_URL=./db/test.db"} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("expected .env to contain %q:\n%s", expected, env)
+		}
+	}
+
+	compose := read("docker-compose.yml")
+	for _, expected := range []string{"\n  psql_bp:\n", "\n  redis_bp:\n", "      BLUEPRINT_REDIS_PORT: ${BLUEPRINT_REDIS_PORT}\n", "      psql_bp:\n        condition: service_healthy\n      redis_bp:\n", "      - sqlite_bp:/app/db\n", "\n  psql_volume_bp:\n  sqlite_bp:\n"} {
+		if !strings.Contains(compose, expected) {
+			t.Errorf("expected docker-compose.yml to contain %q:\n%s", expected, compose)
+		}
+	}
+	if strings.Count(compose, "\n  app:\n") != 1 {
+		t.Errorf("expected a single app service:\n%s", compose)
+	}
+
+	if makefile := read("Makefile"); !strings.Contains(makefile, "go test ./internal/database/... -v") {
+		t.Errorf("expected the integration tests of every store to run:\n%s", makefile)
+	}
+}
+
+func TestCreateMainFileFeatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		framework flags.Framework
+		driver    flags.Database
+		orm       flags.ORM
+		features  []string
+		// files are generated, absent are not
+		files  []string
+		absent []string
+		// wired maps a file to the identifiers hooking the feature in
+		wired   map[string][]string
+		skipped string
+	}{
+		{
+			name:      "migrations",
+			framework: flags.Chi,
+			driver:    "postgres",
+			features:  []string{flags.Migrations},
+			files:     []string{"internal/database/migrate.go", "migrations/migrations.go", "migrations/000001_init.up.sql", "migrations/000001_init.down.sql", "cmd/migrate/main.go"},
+		},
+		{
+			name:      "migrations of several drivers",
+			framework: flags.Chi,
+			driver:    "postgres,redis,sqlite",
+			features:  []string{flags.Migrations},
+			files:     []string{"internal/database/postgres/migrate.go", "internal/database/sqlite/migrate.go", "migrations/postgres/000001_init.up.sql", "migrations/sqlite/000001_init.down.sql", "cmd/migrate/main.go"},
+			absent:    []string{"internal/database/redis/migrate.go"},
+		},
+		{
+			name:      "sqlc",
+			framework: flags.Chi,
+			driver:    "mysql",
+			features:  []string{flags.Sqlc},
+			files:     []string{"sqlc.yaml", "schema/schema.sql", "queries/examples.sql", "internal/database/queries/examples.sql.go"},
+			wired:     map[string][]string{"internal/database/database.go": {"Queries() *queries.Queries"}},
+		},
+		{
+			name:      "sqlc of migrated drivers",
+			framework: flags.Chi,
+			driver:    "postgres,redis,sqlite",
+			features:  []string{flags.Migrations, flags.Sqlc},
+			files:     []string{"queries/postgres/examples.sql", "internal/database/sqlite/queries/models.go"},
+			absent:    []string{"queries/redis/examples.sql"},
+			wired:     map[string][]string{"sqlc.yaml": {"migrations/postgres"}},
+		},
+		{
+			name:      "gorm",
+			framework: flags.Chi,
+			driver:    flags.Postgres,
+			orm:       flags.Gorm,
+			wired:     map[string][]string{"internal/database/database.go": {"gorm.Open("}},
+		},
+		{
+			name:      "bun",
+			framework: flags.Chi,
+			driver:    flags.MySql,
+			orm:       flags.Bun,
+			wired:     map[string][]string{"internal/database/database.go": {"bun.NewDB("}},
+		},
+		{
+			name:      "sqlx",
+			framework: flags.Chi,
+			driver:    flags.Sqlite,
+			orm:       flags.Sqlx,
+			wired:     map[string][]string{"internal/database/database.go": {"sqlx.NewDb("}},
+		},
+		{
+			name:      "ent",
+			framework: flags.Chi,
+			driver:    "postgres,redis",
+			orm:       flags.Ent,
+			files:     []string{"ent/schema/example.go", "ent/generate.go", "ent/tools.go"},
+			wired:     map[string][]string{"internal/database/postgres/database.go": {`"github.com/user/blueprint/ent"`}},
+			skipped:   "go generate ./ent",
+		},
+		{
+			name:      "grpc",
+			framework: flags.Fiber,
+			driver:    "none",
+			features:  []string{flags.Grpc},
+			files:     []string{"buf.gen.yaml", "proto/hello/v1/hello.proto", "internal/grpc/health.go", "internal/grpc/server_test.go"},
+			wired:     map[string][]string{"cmd/api/main.go": {"grpcServer"}},
+		},
+		{
+			name:      "grpc with a database",
+			framework: flags.Gin,
+			driver:    "postgres,redis",
+			features:  []string{flags.Grpc},
+			wired:     map[string][]string{"internal/grpc/server.go": {"database.New(cfg)"}},
+		},
+		{
+			name:      "graphql",
+			framework: flags.Fiber,
+			driver:    "none",
+			features:  []string{flags.Graphql},
+			files:     []string{"gqlgen.yml", "internal/graph/schema.graphqls", "internal/graph/resolver.go", "internal/server/graphql_test.go"},
+			wired:     map[string][]string{"internal/server/routes.go": {"s.graphqlHandler()"}},
+			skipped:   "go generate ./internal/graph",
+		},
+		{
+			name:      "graphql with a database",
+			framework: flags.StandardLibrary,
+			driver:    "postgres,redis",
+			features:  []string{flags.Graphql},
+			files:     []string{"internal/graph/health.go"},
+			wired:     map[string][]string{"internal/server/routes.go": {"s.playgroundHandler()"}},
+			skipped:   "go generate ./internal/graph",
+		},
+		{
+			name:      "auth",
+			framework: flags.Fiber,
+			driver:    "none",
+			features:  []string{flags.Auth},
+			files:     []string{"internal/auth/token.go", "internal/server/auth_handlers.go", "internal/server/auth_handlers_test.go"},
+			wired:     map[string][]string{"internal/server/routes.go": {"s.registerAuthRoutes("}, "internal/server/auth.go": {"auth.NewMemoryStore()"}},
+		},
+		{
+			name:      "auth with a database",
+			framework: flags.Chi,
+			driver:    "sqlite",
+			features:  []string{flags.Auth},
+			files:     []string{"internal/database/users.go"},
+			wired:     map[string][]string{"internal/server/auth.go": {"database.NewUserStore(s.db)"}},
+		},
+		{
+			name:      "auth with migrations",
+			framework: flags.StandardLibrary,
+			driver:    "postgres,redis",
+			features:  []string{flags.Migrations, flags.Auth},
+			files:     []string{"internal/database/postgres/users.go", "migrations/postgres/000002_create_users.up.sql", "migrations/postgres/000002_create_users.down.sql"},
+			wired:     map[string][]string{"internal/server/auth.go": {"postgres.NewUserStore("}},
+		},
+		{
+			name:      "otel",
+			framework: flags.Fiber,
+			driver:    "none",
+			features:  []string{flags.Otel},
+			files:     []string{"internal/telemetry/telemetry.go", "internal/telemetry/telemetry_test.go", "otel-collector.yaml"},
+			wired:     map[string][]string{"internal/server/routes.go": {"otelfiber."}, "cmd/api/main.go": {"shutdownTelemetry("}},
+		},
+		{
+			name:      "otel with docker",
+			framework: flags.Chi,
+			driver:    "postgres",
+			features:  []string{flags.Docker, flags.Otel},
+			wired:     map[string][]string{"internal/server/routes.go": {"otelhttp."}, "internal/database/database.go": {"otelsql."}, "docker-compose.yml": {"OTEL_EXPORTER_OTLP_ENDPOINT"}},
+		},
+		{
+			name:      "otel of several drivers",
+			framework: flags.Gin,
+			driver:    "redis,mongo,scylla",
+			features:  []string{flags.Otel},
+			wired: map[string][]string{
+				"internal/server/routes.go":            {"otelgin."},
+				"internal/database/redis/database.go":  {"redisotel."},
+				"internal/database/mongo/database.go":  {"otelmongo."},
+				"internal/database/scylla/database.go": {"queryTracer"},
+			},
+		},
+		{
+			name:      "metrics",
+			framework: flags.StandardLibrary,
+			driver:    "none",
+			features:  []string{flags.Metrics},
+			files:     []string{"internal/metrics/metrics.go", "internal/metrics/admin.go", "internal/metrics/metrics_test.go", "prometheus.yml"},
+			wired:     map[string][]string{"internal/server/routes.go": {"metrics.Middleware("}, "cmd/api/main.go": {"metrics.NewAdminServer("}},
+		},
+		{
+			name:      "metrics with grpc",
+			framework: flags.Fiber,
+			driver:    "postgres",
+			features:  []string{flags.Docker, flags.Grpc, flags.Metrics},
+			wired:     map[string][]string{"internal/server/routes.go": {"metrics.Handler()"}, "internal/database/database.go": {"NewDBStatsCollector("}},
+		},
+		{
+			name:      "metrics of several drivers",
+			framework: flags.HttpRouter,
+			driver:    "mysql,sqlite",
+			features:  []string{flags.Otel, flags.Metrics},
+			wired: map[string][]string{
+				"internal/server/routes.go":            {"metrics.Middleware("},
+				"internal/database/mysql/database.go":  {"NewDBStatsCollector("},
+				"internal/database/sqlite/database.go": {"NewDBStatsCollector("},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, memory := newTestProject(tt.framework, tt.driver, tt.features...)
+			project.ORM = tt.orm
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+			assertGoFilesParse(t, memory)
+
+			for _, name := range tt.files {
+				if _, err := memory.Stat(filepath.Join("/workspace/blueprint", name)); err != nil {
+					t.Errorf("expected %s: %v", name, err)
+				}
+			}
+			for _, name := range tt.absent {
+				if _, err := memory.Stat(filepath.Join("/workspace/blueprint", name)); err == nil {
+					t.Errorf("did not expect %s", name)
+				}
+			}
+			assertRendered(t, memory, tt.wired)
+			if tt.skipped != "" && !slices.Contains(project.SkippedCommands, tt.skipped) {
+				t.Errorf("expected %q to run, got %v", tt.skipped, project.SkippedCommands)
+			}
+
+			manifest, err := ReadManifest(memory, "/workspace/blueprint")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.ORM != tt.orm {
+				t.Errorf("expected the manifest to record the ORM %q, got %q", tt.orm, manifest.ORM)
+			}
+		})
+	}
+}
+
+func TestCreateMainFileNeedsSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   flags.Database
+		orm      flags.ORM
+		features []string
+	}{
+		{name: "migrations", driver: flags.Redis, features: []string{flags.Migrations}},
+		{name: "sqlc", driver: flags.Mongo, features: []string{flags.Sqlc}},
+		{name: "orm", driver: flags.Redis, orm: flags.Gorm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, _ := newTestProject(flags.Chi, tt.driver, tt.features...)
+			project.ORM = tt.orm
+			if err := project.CreateMainFile(); err == nil {
+				t.Errorf("expected %s to need a SQL driver, got %s", tt.name, tt.driver)
+			}
+		})
+	}
+}
+
+// TestCreateMainFileDefaults checks the logger, configuration and health
+// checks every project gets
+func TestCreateMainFileDefaults(t *testing.T) {
+	for _, framework := range flags.AllowedProjectTypes {
+		t.Run(framework, func(t *testing.T) {
+			t.Parallel()
+
+			project, memory := newTestProject(flags.Framework(framework), "postgres", flags.Auth)
+			if err := project.CreateMainFile(); err != nil {
+				t.Fatalf("could not create project: %v", err)
+			}
+
+			assertRendered(t, memory, map[string][]string{
+				"internal/logger/logger_test.go": nil,
+				"internal/config/config_test.go": nil,
+				"internal/health/health_test.go": nil,
+				"internal/server/routes.go":      {"logger.Middleware", "/readyz", "/livez"},
+				"cmd/api/main.go":                {"logger.Setup()", "config.Load()", "checker.SetReady(false)"},
+			})
+
+			// The settings are only read by the config package
+			for _, name := range []string{"internal/database/database.go", "internal/server/server.go", "internal/server/auth.go", "cmd/api/main.go"} {
+				content, err := memory.ReadFile(filepath.Join("/workspace/blueprint", name))
+				if err != nil {
+					t.Errorf("expected %s: %v", name, err)
+					continue
+				}
+				if strings.Contains(string(content), "os.Getenv(") {
+					t.Errorf("expected %s to read its settings from the config:\n%s", name, content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package program
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/template/dbdriver"
+	"github.com/melkeydev/go-blueprint/cmd/template/docker"
+	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+	"github.com/melkeydev/go-blueprint/cmd/template/orm"
+)
+
+// A RegisteredFramework is a framework projects can be generated with
+type RegisteredFramework struct {
+	Name        flags.Framework
+	Title       string // Name displayed in the interactive steps
+	Description string
+	Packages    []string // Packages fetched with "go get"
+	Templater   Templater
+}
+
+// A RegisteredDriver describes a database driver projects can be
+// generated with, and what its database needs from the project
+type RegisteredDriver struct {
+	Name        flags.Database
+	Title       string // Name displayed in the interactive steps
+	Description string
+	Packages    []string // Packages fetched with "go get"
+	// Replacements are the module replacements the driver needs,
+	// written old=new@version as "go mod edit -replace" takes them
+	Replacements []string
+	// Templater provides the database service, its .env
+	// variables and its integration tests
+	Templater DBDriverTemplater
+	// Compose provides the docker-compose.yml service of the
+	// database, nil when it does not run in its own container
+	Compose DockerTemplater
+	// NeedsCGO is set for drivers that only build with cgo enabled
+	NeedsCGO bool
+	// TelemetryPackages are the packages fetched with "go get" by
+	// the otel feature, instrumenting the client of the database
+	TelemetryPackages []string
+}
+
+// A RegisteredORM describes an ORM the services of the
+// SQL databases can be generated with, over database/sql
+type RegisteredORM struct {
+	Name        flags.ORM
+	Title       string // Name displayed in the interactive steps
+	Description string
+	// Packages are the packages fetched with "go get" for every
+	// database using the ORM, by driver. The ORM is only used by
+	// the databases of the drivers it has packages for
+	Packages map[flags.Database][]string
+	// Templater provides the blocks completing the
+	// service and the tests of the databases
+	Templater ORMTemplater
+}
+
+// SupportsCompose reports whether the database
+// runs as a service of docker-compose.yml
+func (d RegisteredDriver) SupportsCompose() bool {
+	return d.Compose != nil
+}
+
+// Embedded reports whether the database runs inside the
+// application and keeps its data in files, like SQLite
+func (d RegisteredDriver) Embedded() bool {
+	return d.Templater != nil && d.Compose == nil
+}
+
+var (
+	// frameworks holds the registered frameworks, in registration order
+	frameworks []RegisteredFramework
+	// drivers holds the registered drivers, in registration order
+	drivers []RegisteredDriver
+	// orms holds the registered ORMs, in registration order
+	orms []RegisteredORM
+)
+
+func init() {
+	Register(string(flags.StandardLibrary), []string{}, framework.StandardLibTemplate{}, "The built-in Go standard library HTTP package")
+	Register(string(flags.Chi), chiPackage, framework.ChiTemplates{}, "A lightweight, idiomatic and composable router for building Go HTTP services")
+	Register(string(flags.Gin), ginPackage, framework.GinTemplates{}, "Features a martini-like API with performance that is up to 40 times faster thanks to httprouter")
+	Register(string(flags.Fiber), fiberPackage, framework.FiberTemplates{}, "An Express inspired web framework built on top of Fasthttp")
+	Register(string(flags.GorillaMux), gorillaPackage, framework.GorillaTemplates{}, "Package gorilla/mux implements a request router and dispatcher for matching incoming requests to their respective handler")
+	Register(string(flags.HttpRouter), routerPackage, framework.RouterTemplates{}, "HttpRouter is a lightweight high performance HTTP request router for Go")
+	Register(string(flags.Echo), echoPackage, framework.EchoTemplates{}, "High performance, extensible, minimalist Go web framework")
+
+	RegisterDriver(RegisteredDriver{
+		Name:              flags.MySql,
+		Description:       "MySQL-Driver for Go's database/sql package",
+		Packages:          mysqlDriver,
+		Templater:         dbdriver.MysqlTemplate{},
+		Compose:           docker.MysqlDockerTemplate{},
+		TelemetryPackages: otelsqlPackage,
+	})
+	RegisterDriver(RegisteredDriver{
+		Name:              flags.Postgres,
+		Description:       "Go postgres driver for Go's database/sql package",
+		Packages:          postgresDriver,
+		Templater:         dbdriver.PostgresTemplate{},
+		Compose:           docker.PostgresDockerTemplate{},
+		TelemetryPackages: otelsqlPackage,
+	})
+	RegisterDriver(RegisteredDriver{
+		Name:              flags.Sqlite,
+		Description:       "sqlite3 driver conforming to the built-in database/sql interface",
+		Packages:          sqliteDriver,
+		Templater:         dbdriver.SqliteTemplate{},
+		NeedsCGO:          true,
+		TelemetryPackages: otelsqlPackage,
+	})
+	RegisterDriver(RegisteredDriver{
+		Name:              flags.Mongo,
+		Description:       "The MongoDB supported driver for Go.",
+		Packages:          mongoDriver,
+		Templater:         dbdriver.MongoTemplate{},
+		Compose:           docker.MongoDockerTemplate{},
+		TelemetryPackages: []string{"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"},
+	})
+	RegisterDriver(RegisteredDriver{
+		Name:              flags.Redis,
+		Description:       "Redis driver for Go.",
+		Packages:          redisDriver,
+		Templater:         dbdriver.RedisTemplate{},
+		Compose:           docker.RedisDockerTemplate{},
+		TelemetryPackages: []string{"github.com/redis/go-redis/extra/redisotel/v9"},
+	})
+	RegisterDriver(RegisteredDriver{
+		Name:         flags.Scylla,
+		Description:  "ScyllaDB Enhanced driver from GoCQL.",
+		Packages:     gocqlDriver,
+		Replacements: []string{gocqlDriver[0] + "=" + scyllaDriver},
+		Templater:    dbdriver.ScyllaTemplate{},
+		Compose:      docker.ScyllaDockerTemplate{},
+	})
+
+	RegisterORM(RegisteredORM{
+		Name:        flags.Gorm,
+		Title:       "GORM",
+		Description: "The fantastic ORM library for Golang, aims to be developer friendly",
+		Packages: map[flags.Database][]string{
+			flags.Postgres: {"gorm.io/gorm", "gorm.io/driver/postgres"},
+			flags.MySql:    {"gorm.io/gorm", "gorm.io/driver/mysql"},
+			flags.Sqlite:   {"gorm.io/gorm", "gorm.io/driver/sqlite"},
+		},
+		Templater: orm.GormTemplate{},
+	})
+	RegisterORM(RegisteredORM{
+		Name:        flags.Ent,
+		Description: "An entity framework for Go, generating a typed client from the schema of the entities",
+		Packages: map[flags.Database][]string{
+			flags.Postgres: entPackage,
+			flags.MySql:    entPackage,
+			flags.Sqlite:   entPackage,
+		},
+		Templater: orm.EntTemplate{},
+	})
+	RegisterORM(RegisteredORM{
+		Name:        flags.Bun,
+		Description: "SQL-first Golang ORM, with query builders for every statement",
+		Packages: map[flags.Database][]string{
+			flags.Postgres: {"github.com/uptrace/bun", "github.com/uptrace/bun/dialect/pgdialect"},
+			flags.MySql:    {"github.com/uptrace/bun", "github.com/uptrace/bun/dialect/mysqldialect"},
+			flags.Sqlite:   {"github.com/uptrace/bun", "github.com/uptrace/bun/dialect/sqlitedialect"},
+		},
+		Templater: orm.BunTemplate{},
+	})
+	RegisterORM(RegisteredORM{
+		Name:        flags.Sqlx,
+		Description: "General purpose extensions to database/sql, scanning rows into structs",
+		Packages: map[flags.Database][]string{
+			flags.Postgres: sqlxPackage,
+			flags.MySql:    sqlxPackage,
+			flags.Sqlite:   sqlxPackage,
+		},
+		Templater: orm.SqlxTemplate{},
+	})
+}
+
+// Register makes a framework available to the --framework flag, its
+// shell completion, the interactive steps and project generation.
+// packages are fetched with "go get" into generated projects, and
+// templater provides the files specific to the framework. The title
+// is the capitalized name, unless templater is a TitledTemplater.
+//
+// Register is meant to be called from an init function of this
+// package, so that the framework is known before the commands are
+// set up. It panics when name is empty, not lowercase, or already
+// registered, or without templater
+func Register(name string, packages []string, templater Templater, description string) {
+	if name == "" || name != strings.ToLower(name) {
+		panic(fmt.Sprintf("program: invalid framework name %q, it must be lowercase", name))
+	}
+	if templater == nil {
+		panic(fmt.Sprintf("program: framework %s registered without templater", name))
+	}
+	if slices.Contains(flags.AllowedProjectTypes, name) {
+		panic(fmt.Sprintf("program: framework %s registered twice", name))
+	}
+
+	frameworkTitle := title(name)
+	if titled, ok := templater.(TitledTemplater); ok {
+		frameworkTitle = titled.Title()
+	}
+	frameworks = append(frameworks, RegisteredFramework{
+		Name:        flags.Framework(name),
+		Title:       frameworkTitle,
+		Description: description,
+		Packages:    packages,
+		Templater:   templater,
+	})
+	flags.AllowedProjectTypes = append(flags.AllowedProjectTypes, name)
+}
+
+// Frameworks returns the registered frameworks, in registration order
+func Frameworks() []RegisteredFramework {
+	return slices.Clone(frameworks)
+}
+
+// RegisterDriver makes a database driver available to the --driver
+// flag, its shell completion, the interactive steps and project
+// generation. The title defaults to the capitalized name.
+//
+// Like Register, RegisterDriver is meant to be called from an init
+// function of this package. It panics when the name is empty, not
+// lowercase, none or already registered, or without templater
+func RegisterDriver(driver RegisteredDriver) {
+	name := string(driver.Name)
+	if name == "" || name != strings.ToLower(name) || driver.Name == flags.None {
+		panic(fmt.Sprintf("program: invalid driver name %q, it must be lowercase and not %s", name, flags.None))
+	}
+	if driver.Templater == nil {
+		panic(fmt.Sprintf("program: driver %s registered without templater", name))
+	}
+	if slices.Contains(flags.AllowedDBDrivers, name) {
+		panic(fmt.Sprintf("program: driver %s registered twice", name))
+	}
+
+	if driver.Title == "" {
+		driver.Title = title(name)
+	}
+	drivers = append(drivers, driver)
+	// none stays the last choice
+	flags.AllowedDBDrivers = slices.Insert(flags.AllowedDBDrivers, len(flags.AllowedDBDrivers)-1, name)
+}
+
+// Drivers returns the registered drivers, in registration order
+func Drivers() []RegisteredDriver {
+	return slices.Clone(drivers)
+}
+
+// RegisterORM makes an ORM available to the --orm flag, its shell
+// completion, the interactive steps and project generation. The
+// title defaults to the capitalized name.
+//
+// Like Register, RegisterORM is meant to be called from an init
+// function of this package. It panics when the name is empty, not
+// lowercase, none or already registered, or without templater
+func RegisterORM(o RegisteredORM) {
+	name := string(o.Name)
+	if name == "" || name != strings.ToLower(name) || o.Name == flags.NoORM {
+		panic(fmt.Sprintf("program: invalid ORM name %q, it must be lowercase and not %s", name, flags.NoORM))
+	}
+	if o.Templater == nil {
+		panic(fmt.Sprintf("program: ORM %s registered without templater", name))
+	}
+	if slices.Contains(flags.AllowedORMs, name) {
+		panic(fmt.Sprintf("program: ORM %s registered twice", name))
+	}
+
+	if o.Title == "" {
+		o.Title = title(name)
+	}
+	orms = append(orms, o)
+	// none stays the last choice
+	flags.AllowedORMs = slices.Insert(flags.AllowedORMs, len(flags.AllowedORMs)-1, name)
+}
+
+// ORMs returns the registered ORMs, in registration order
+func ORMs() []RegisteredORM {
+	return slices.Clone(orms)
+}
+
+// SupportsORM reports whether an ORM is
+// registered for one of the project databases
+func (p *Project) SupportsORM() bool {
+	for _, o := range orms {
+		for _, store := range p.Stores() {
+			if _, ok := o.Packages[store.DBDriver]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Driver returns the registered driver of the project, the
+// zero RegisteredDriver when it has no database or several
+func (p *Project) Driver() RegisteredDriver {
+	for _, driver := range drivers {
+		if driver.Name == p.DBDriver {
+			return driver
+		}
+	}
+	return RegisteredDriver{}
+}
+
+// title capitalizes every path element of a framework, driver or ORM name,
+// turning gorilla/mux into Gorilla/Mux. The interactive steps lowercase
+// the selected title back into the name
+func title(name string) string {
+	elements := strings.Split(name, "/")
+	for i, element := range elements {
+		if element != "" {
+			elements[i] = strings.ToUpper(element[:1]) + element[1:]
+		}
+	}
+	return strings.Join(elements, "/")
+}
@@ -0,0 +1,183 @@
+package program
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/template/dbdriver"
+	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+	"github.com/melkeydev/go-blueprint/cmd/template/orm"
+)
+
+func TestRegister(t *testing.T) {
+	registered, allowed := frameworks, flags.AllowedProjectTypes
+	t.Cleanup(func() {
+		frameworks, flags.AllowedProjectTypes = registered, allowed
+	})
+
+	Register("acme/router", []string{"github.com/acme/router"}, framework.StandardLibTemplate{}, "The router of ACME")
+
+	if !slices.Contains(flags.AllowedProjectTypes, "acme/router") {
+		t.Errorf("expected acme/router in the allowed frameworks, got %v", flags.AllowedProjectTypes)
+	}
+	var flagFramework flags.Framework
+	if err := flagFramework.Set("acme/router"); err != nil {
+		t.Errorf("expected the framework flag to accept acme/router: %v", err)
+	}
+
+	if titles := frameworkTitles(); !slices.Contains(titles, "HttpRouter") || !slices.Contains(titles, "Gorilla/Mux") {
+		t.Errorf("expected the built-in frameworks to keep their titles, got %v", titles)
+	}
+
+	last := Frameworks()[len(Frameworks())-1]
+	if last.Name != "acme/router" || last.Title != "Acme/Router" {
+		t.Errorf("expected acme/router titled Acme/Router, got %s titled %s", last.Name, last.Title)
+	}
+
+	project, memory := newTestProject("acme/router", flags.None)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project with a registered framework: %v", err)
+	}
+	if !slices.Contains(project.SkippedCommands, "go get -u github.com/acme/router") {
+		t.Errorf("expected the framework packages to be fetched, got %v", project.SkippedCommands)
+	}
+	assertGoFilesParse(t, memory)
+
+	for _, name := range []string{"acme/router", "Upper", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected registering %q to panic", name)
+				}
+			}()
+			Register(name, nil, framework.StandardLibTemplate{}, "")
+		}()
+	}
+}
+
+func frameworkTitles() []string {
+	var titles []string
+	for _, f := range Frameworks() {
+		titles = append(titles, f.Title)
+	}
+	return titles
+}
+
+func TestRegisterDriver(t *testing.T) {
+	registered, allowed := drivers, flags.AllowedDBDrivers
+	t.Cleanup(func() {
+		drivers, flags.AllowedDBDrivers = registered, allowed
+	})
+
+	RegisterDriver(RegisteredDriver{
+		Name:         "acmedb",
+		Packages:     []string{"github.com/acme/acmedb"},
+		Replacements: []string{"github.com/acme/acmedb=github.com/fork/acmedb@v1.0.0"},
+		Templater:    dbdriver.SqliteTemplate{},
+		NeedsCGO:     true,
+	})
+
+	if last := flags.AllowedDBDrivers[len(flags.AllowedDBDrivers)-1]; last != string(flags.None) {
+		t.Errorf("expected none to stay the last driver, got %v", flags.AllowedDBDrivers)
+	}
+	var flagDriver flags.Database
+	if err := flagDriver.Set("acmedb"); err != nil {
+		t.Errorf("expected the driver flag to accept acmedb: %v", err)
+	}
+
+	project, memory := newTestProject(flags.Chi, "acmedb", flags.Docker)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project with a registered driver: %v", err)
+	}
+	if driver := project.Driver(); driver.Title != "Acmedb" || driver.SupportsCompose() || !driver.Embedded() {
+		t.Errorf("unexpected driver %+v", driver)
+	}
+	if !slices.Contains(project.SkippedCommands, "go get -u github.com/acme/acmedb") {
+		t.Errorf("expected the driver packages to be fetched, got %v", project.SkippedCommands)
+	}
+
+	goMod, err := memory.ReadFile("/workspace/blueprint/go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goMod), "replace github.com/acme/acmedb => github.com/fork/acmedb v1.0.0") {
+		t.Errorf("expected the driver replacement in go.mod:\n%s", goMod)
+	}
+	dockerfile, err := memory.ReadFile("/workspace/blueprint/Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dockerfile), "CGO_ENABLED=1") {
+		t.Errorf("expected cgo to be enabled in the Dockerfile:\n%s", dockerfile)
+	}
+	compose, err := memory.ReadFile("/workspace/blueprint/docker-compose.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(compose), "image:") || !strings.Contains(string(compose), "acmedb_bp:") {
+		t.Errorf("expected the app-only compose file with a data volume:\n%s", compose)
+	}
+
+	for _, name := range []string{"acmedb", string(flags.None), "Upper", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected registering %q to panic", name)
+				}
+			}()
+			RegisterDriver(RegisteredDriver{Name: flags.Database(name), Templater: dbdriver.SqliteTemplate{}})
+		}()
+	}
+}
+
+func TestRegisterORM(t *testing.T) {
+	registered, allowed := orms, flags.AllowedORMs
+	t.Cleanup(func() {
+		orms, flags.AllowedORMs = registered, allowed
+	})
+
+	RegisterORM(RegisteredORM{
+		Name:      "acmeorm",
+		Packages:  map[flags.Database][]string{flags.Sqlite: {"github.com/acme/acmeorm"}},
+		Templater: orm.SqlxTemplate{},
+	})
+
+	if last := flags.AllowedORMs[len(flags.AllowedORMs)-1]; last != string(flags.NoORM) {
+		t.Errorf("expected none to stay the last ORM, got %v", flags.AllowedORMs)
+	}
+	var flagORM flags.ORM
+	if err := flagORM.Set("acmeorm"); err != nil {
+		t.Errorf("expected the orm flag to accept acmeorm: %v", err)
+	}
+
+	project, _ := newTestProject(flags.Chi, "postgres,sqlite")
+	project.ORM = "acmeorm"
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project with a registered ORM: %v", err)
+	}
+	if stores := project.ORMStores(); len(stores) != 1 || stores[0].DBDriver != flags.Sqlite {
+		t.Errorf("expected the ORM to only be used by the sqlite store, got %v", stores)
+	}
+	if !slices.Contains(project.SkippedCommands, "go get -u github.com/acme/acmeorm") {
+		t.Errorf("expected the ORM packages to be fetched, got %v", project.SkippedCommands)
+	}
+
+	project, _ = newTestProject(flags.Chi, flags.Postgres)
+	project.ORM = "acmeorm"
+	if err := project.CreateMainFile(); err == nil {
+		t.Error("expected an error for an ORM not supporting the database")
+	}
+
+	for _, name := range []string{"acmeorm", string(flags.NoORM), "Upper", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected registering %q to panic", name)
+				}
+			}()
+			RegisterORM(RegisteredORM{Name: flags.ORM(name), Templater: orm.SqlxTemplate{}})
+		}()
+	}
+}
@@ -0,0 +1,613 @@
+package program
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/template/dbdriver"
+	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+	"github.com/melkeydev/go-blueprint/cmd/utils"
+)
+
+// AllowedFieldTypes lists the types of the fields of a resource.
+// time is a time.Time, the others are the Go types of that name
+var AllowedFieldTypes = []string{"string", "int", "int64", "float64", "bool", "time"}
+
+// A Resource is an entity "generate resource" scaffolds the CRUD
+// endpoints of, from its model and repository to its routes
+type Resource struct {
+	Name      string // Name in words, like "blog post"
+	Plural    string // Plural name in words, like "blog posts"
+	GoName    string // Exported Go name, BlogPost
+	GoPlural  string // BlogPosts
+	VarName   string // Name of a local variable holding one, blogPost
+	VarPlural string // blogPosts
+	File      string // Base name of the generated files, blog_post
+	Table     string // Table, collection or key prefix, blog_posts
+	Route     string // Path of the routes, /blog-posts
+	Fields    []ResourceField
+}
+
+// A ResourceField is a field of a Resource, besides its id
+type ResourceField struct {
+	Name   string // JSON name and column, as given
+	GoName string
+	Type   string // One of AllowedFieldTypes
+}
+
+// A ResourcePlan contains the files generating a resource writes
+// into an existing project
+type ResourcePlan struct {
+	ProjectPath string
+	FS          filesystem.FileSystem // File system holding the project
+	Files       []PlannedFile
+	// Registration is the statement of RegisterRoutes registering the
+	// routes of the resource. It is added to routes.go when Routes is
+	// set, and has to be added by hand otherwise, RoutesError telling
+	// why routes.go could not be updated
+	Registration string
+	Routes       *PlannedFile
+	RoutesError  error
+}
+
+// resourceData is the data the templates of a resource are rendered
+// with: the Store holding the resource, along with the resource
+type resourceData struct {
+	Store
+	Resource *Resource
+}
+
+var (
+	resourceNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	fieldNamePattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	registrationPattern = regexp.MustCompile(`^register\w+Routes$`)
+
+	// initialisms are the words written in upper case in Go names
+	initialisms = []string{"api", "html", "http", "id", "ip", "json", "sql", "uid", "url", "uri", "uuid"}
+
+	// reservedVarNames are the identifiers the generated code uses,
+	// which the local variables named after a resource would shadow
+	reservedVarNames = []string{
+		"app", "applied", "body", "c", "collection", "ctx", "cursor", "db", "deleted", "err", "f", "h", "i", "id",
+		"ids", "iter", "key", "keys", "member", "members", "n", "pipe", "r", "repo", "req", "result", "rows", "rr",
+		"s", "serve", "session", "statement", "status", "t", "tests", "tt", "unknown", "value", "values", "w",
+		"bson", "chi", "context", "database", "echo", "errors", "fiber", "gin", "gocql", "http", "httprouter",
+		"httptest", "json", "log", "mongo", "mux", "mysql", "options", "postgres", "primitive", "redis", "scylla",
+		"slices", "sql", "sqlite", "strconv", "strings", "sync", "testing", "time",
+	}
+)
+
+// ParseResource returns the Resource with the given name, singular,
+// and fields, given as comma separated name:type pairs
+func ParseResource(name string, fields string) (*Resource, error) {
+	if !resourceNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name '%s', use letters, digits, underscores and dashes", name)
+	}
+
+	words := splitWords(name)
+	pluralWords := slices.Clone(words)
+	pluralWords[len(words)-1] = pluralize(words[len(words)-1])
+
+	r := &Resource{
+		Name:      strings.Join(words, " "),
+		Plural:    strings.Join(pluralWords, " "),
+		GoName:    goName(words),
+		GoPlural:  goName(pluralWords),
+		VarName:   varName(words),
+		VarPlural: varName(pluralWords),
+		File:      strings.Join(words, "_"),
+		Table:     strings.Join(pluralWords, "_"),
+		Route:     "/" + strings.Join(pluralWords, "-"),
+	}
+	for _, v := range []string{r.VarName, r.VarPlural} {
+		if token.IsKeyword(v) || slices.Contains(reservedVarNames, v) {
+			return nil, fmt.Errorf("invalid resource name '%s', '%s' is used by the generated code", name, v)
+		}
+	}
+
+	if strings.TrimSpace(fields) == "" {
+		return nil, fmt.Errorf("the %s resource needs at least one field", r.Name)
+	}
+	for _, pair := range strings.Split(fields, ",") {
+		fieldName, fieldType, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || !fieldNamePattern.MatchString(fieldName) {
+			return nil, fmt.Errorf("invalid field '%s', fields are given as name:type", pair)
+		}
+		if !slices.Contains(AllowedFieldTypes, fieldType) {
+			return nil, fmt.Errorf("invalid type '%s' of field %s. Allowed types: %s", fieldType, fieldName, strings.Join(AllowedFieldTypes, ", "))
+		}
+
+		field := ResourceField{Name: fieldName, GoName: goName(splitWords(fieldName)), Type: fieldType}
+		if field.GoName == "ID" {
+			return nil, fmt.Errorf("invalid field '%s', resources get their id from the database", fieldName)
+		}
+		if slices.ContainsFunc(r.Fields, func(f ResourceField) bool { return f.GoName == field.GoName }) {
+			return nil, fmt.Errorf("duplicate field '%s'", fieldName)
+		}
+		r.Fields = append(r.Fields, field)
+	}
+
+	return r, nil
+}
+
+// GoType returns the Go type of the field
+func (f ResourceField) GoType() string {
+	if f.Type == "time" {
+		return "time.Time"
+	}
+	return f.Type
+}
+
+// Sample returns a Go expression of a value of the field
+func (f ResourceField) Sample() string {
+	switch f.Type {
+	case "string":
+		return strconv.Quote(f.Name)
+	case "float64":
+		return "1.5"
+	case "bool":
+		return "true"
+	case "time":
+		return "time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)"
+	default:
+		return "1"
+	}
+}
+
+// SampleJSON returns the JSON of the value of Sample
+func (f ResourceField) SampleJSON() string {
+	if f.Type == "time" {
+		return `"2024-01-01T00:00:00Z"`
+	}
+	return f.Sample()
+}
+
+// Uses reports whether a field of the resource has the given type
+func (r *Resource) Uses(fieldType string) bool {
+	return slices.ContainsFunc(r.Fields, func(f ResourceField) bool { return f.Type == fieldType })
+}
+
+// Columns returns the comma separated columns of the fields
+func (r *Resource) Columns() string {
+	var columns []string
+	for _, field := range r.Fields {
+		columns = append(columns, field.Name)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// Placeholders returns the comma separated placeholders of the
+// fields in a SQL statement, $1, $2 when numbered and ?, ? otherwise
+func (r *Resource) Placeholders(numbered bool) string {
+	var placeholders []string
+	for i := range r.Fields {
+		placeholders = append(placeholders, placeholder(i+1, numbered))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// Assignments returns the SET clause of a SQL statement updating
+// the fields, followed by the placeholder of the id in IDPlaceholder
+func (r *Resource) Assignments(numbered bool) string {
+	var assignments []string
+	for i, field := range r.Fields {
+		assignments = append(assignments, field.Name+" = "+placeholder(i+1, numbered))
+	}
+	return strings.Join(assignments, ", ")
+}
+
+// IDPlaceholder returns the placeholder of the id following Assignments
+func (r *Resource) IDPlaceholder(numbered bool) string {
+	return placeholder(len(r.Fields)+1, numbered)
+}
+
+// SampleBody returns a JSON document holding a sample of every field
+func (r *Resource) SampleBody() string {
+	var members []string
+	for _, field := range r.Fields {
+		members = append(members, strconv.Quote(field.Name)+":"+field.SampleJSON())
+	}
+	return "{" + strings.Join(members, ",") + "}"
+}
+
+func placeholder(n int, numbered bool) string {
+	if numbered {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// Migrated reports whether the table of the resource is created by a
+// migration, rather than by the repository when it is created
+func (d resourceData) Migrated() bool {
+	return d.AdvancedOptions[flags.Migrations] && slices.ContainsFunc(d.MigratedStores(), func(s Store) bool {
+		return s.DBDriver == d.DBDriver
+	})
+}
+
+// DatabaseName returns the name of the database, or keyspace,
+// the resources are stored in, for the drivers needing one
+func (d resourceData) DatabaseName() string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, utils.GetRootDir(d.ProjectName))
+}
+
+// NewRepository returns the expression of a Server method
+// creating the repository of the resource
+func (d resourceData) NewRepository() string {
+	constructor := d.DatabasePackage() + ".New" + d.Resource.GoName + "Repository"
+	switch {
+	case d.DBDriver == flags.None:
+		return constructor + "()"
+	case d.MultipleDrivers():
+		return constructor + "(s.db." + d.GoName() + "())"
+	default:
+		return constructor + "(s.db)"
+	}
+}
+
+// PlanResource renders the files of the resource for the project at
+// projectPath, stored in the database of the given driver, the first
+// one of the project when empty. The registration of its routes is
+// added to routes.go, when RegisterRoutes can be found in it
+func (p *Project) PlanResource(projectPath string, resource *Resource, driver flags.Database) (*ResourcePlan, error) {
+	templater, ok := p.FrameworkMap[p.ProjectType].templater.(ResourceTemplater)
+	if !ok {
+		return nil, fmt.Errorf("framework '%s' does not support resources", p.ProjectType)
+	}
+
+	stores := p.Stores()
+	if p.DBDriver == flags.None {
+		stores = []Store{{Project: p, DBDriver: flags.None}}
+	}
+	if driver == "" {
+		driver = stores[0].DBDriver
+	}
+	index := slices.IndexFunc(stores, func(s Store) bool { return s.DBDriver == driver })
+	if index < 0 {
+		return nil, fmt.Errorf("the project does not use the database '%s'", driver)
+	}
+	data := resourceData{Store: stores[index], Resource: resource}
+
+	repository, repositoryBlocks := dbdriver.MemoryRepositoryTemplate(), []byte(nil)
+	if driver != flags.None {
+		repositoryTemplater, ok := data.Driver().Templater.(RepositoryTemplater)
+		if !ok {
+			return nil, fmt.Errorf("database '%s' does not support resources", driver)
+		}
+		repository, repositoryBlocks = repositoryTemplater.Repository(), repositoryTemplater.RepositoryBlocks()
+	}
+	blocks := [][]byte{dbdriver.RepositoryBlocksTemplate(), repositoryBlocks}
+
+	call := fmt.Sprintf("s.register%sRoutes(%s)", resource.GoName, templater.ResourceRouter())
+	plan := &ResourcePlan{ProjectPath: projectPath, FS: p.FS}
+	add := func(rel string, templateBytes []byte, blocks ...[]byte) error {
+		if _, err := p.FS.Stat(filepath.Join(projectPath, filepath.FromSlash(rel))); err == nil {
+			return fmt.Errorf("%s already exists", rel)
+		}
+
+		content, err := execute(path.Base(rel), templateBytes, data, blocks...)
+		if err != nil {
+			return err
+		}
+		if path.Ext(rel) == ".go" {
+			if content, err = format.Source(content); err != nil {
+				return fmt.Errorf("could not format %s: %w", rel, err)
+			}
+		}
+		plan.Files = append(plan.Files, PlannedFile{Path: rel, Content: content})
+		return nil
+	}
+
+	err := add(path.Join(data.Path(), resource.File+".go"), repository, blocks...)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Migrated() {
+		version, err := lastMigration(filepath.Join(projectPath, migrationsPath, filepath.FromSlash(data.Dir())))
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%06d_create_%s", version+1, resource.Table)
+		err = add(path.Join(migrationsPath, data.Dir(), name+".up.sql"), dbdriver.ResourceUpMigrationTemplate(), blocks...)
+		if err != nil {
+			return nil, err
+		}
+		err = add(path.Join(migrationsPath, data.Dir(), name+".down.sql"), dbdriver.ResourceDownMigrationTemplate(), blocks...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = add(path.Join(internalServerPath, resource.File+"_handlers.go"), templater.ResourceHandlers())
+	if err != nil {
+		return nil, err
+	}
+	testsBlocks := append([][]byte{framework.ResourceTestCasesTemplate()}, blocks...)
+	err = add(path.Join(internalServerPath, resource.File+"_handlers_test.go"), templater.ResourceTests(), testsBlocks...)
+	if err != nil {
+		return nil, err
+	}
+
+	routesPath := path.Join(internalServerPath, "routes.go")
+	routes, err := p.FS.ReadFile(filepath.Join(projectPath, filepath.FromSlash(routesPath)))
+	if err != nil {
+		return nil, err
+	}
+	content, statement, err := addRegistration(routes, call)
+	plan.Registration = statement
+	if err != nil {
+		plan.RoutesError = err
+	} else {
+		plan.Routes = &PlannedFile{Path: routesPath, Content: content, Exists: true}
+	}
+
+	return plan, nil
+}
+
+// Apply writes the files of the resource into the project directory
+// of FS
+func (plan *ResourcePlan) Apply() error {
+	files := plan.Files
+	if plan.Routes != nil {
+		files = append(slices.Clone(files), *plan.Routes)
+	}
+
+	for _, file := range files {
+		if err := writeProjectFile(plan.FS, plan.ProjectPath, file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registration returns the statement registering the routes of a
+// resource with call, which returns the error creating their
+// repository, in a RegisterRoutes declared with results. When
+// RegisterRoutes returns an error, the statement returns the one of
+// call, with nil for the other results. Otherwise, like in the
+// RegisterRoutes of the generated projects returning the http.Handler
+// alone, the server cannot start without the routes and the
+// statement panics with the error
+func registration(call string, results *ast.FieldList) string {
+	failure := "panic(err)"
+	if values, ok := errorResults(results); ok {
+		failure = "return " + values
+	}
+	return fmt.Sprintf("if err := %s; err != nil {\n\t%s\n}", call, failure)
+}
+
+// errorResults returns the values returning err from a function
+// declared with results, when the last one is an error and the others
+// can be nil
+func errorResults(results *ast.FieldList) (string, bool) {
+	var types []ast.Expr
+	for _, field := range results.List {
+		for range max(len(field.Names), 1) {
+			types = append(types, field.Type)
+		}
+	}
+	if len(types) == 0 {
+		return "", false
+	}
+	if last, ok := types[len(types)-1].(*ast.Ident); !ok || last.Name != "error" {
+		return "", false
+	}
+
+	values := make([]string, 0, len(types))
+	for _, typ := range types[:len(types)-1] {
+		if !nillable(typ) {
+			return "", false
+		}
+		values = append(values, "nil")
+	}
+	return strings.Join(append(values, "err"), ", "), true
+}
+
+// nillable reports whether nil is a value of typ, for the types
+// RegisterRoutes may return along with an error
+func nillable(typ ast.Expr) bool {
+	switch typ := typ.(type) {
+	case *ast.StarExpr, *ast.InterfaceType, *ast.MapType, *ast.FuncType, *ast.ChanType:
+		return true
+	case *ast.ArrayType:
+		return typ.Len == nil
+	case *ast.Ident:
+		return typ.Name == "error" || typ.Name == "any"
+	case *ast.SelectorExpr:
+		pkg, ok := typ.X.(*ast.Ident)
+		return ok && pkg.Name == "http" && typ.Sel.Name == "Handler"
+	}
+	return false
+}
+
+// addRegistration adds the registration of call at the end of
+// RegisterRoutes, or RegisterFiberRoutes, in the source of routes.go,
+// returning the updated source and the statement added. Statements
+// registering routes are kept together, before the return statement.
+// When routes.go cannot be updated, the statement is the one to add
+// by hand
+func addRegistration(source []byte, call string) ([]byte, string, error) {
+	statement := registration(call, &ast.FieldList{})
+	if bytes.Contains(source, []byte(call)) {
+		return nil, statement, fmt.Errorf("%s is already in routes.go", call)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", source, parser.ParseComments)
+	if err != nil {
+		return nil, statement, fmt.Errorf("could not parse routes.go: %w", err)
+	}
+
+	var register *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && (fn.Name.Name == "RegisterRoutes" || fn.Name.Name == "RegisterFiberRoutes") {
+			register = fn
+		}
+	}
+	if register == nil || register.Body == nil {
+		return nil, statement, errors.New("RegisterRoutes is missing from routes.go")
+	}
+	results := register.Type.Results
+	if results == nil {
+		results = &ast.FieldList{}
+	}
+	body, statement := register.Body, registration(call, results)
+
+	tokenFile := fset.File(body.Pos())
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+	lineStart := func(line int) int { return tokenFile.Offset(tokenFile.LineStart(line)) }
+
+	offset, insert := lineStart(line(body.Rbrace)), "\n\t"+statement+"\n"
+	if n := len(body.List); n > 0 {
+		last := body.List[n-1]
+		_, returns := last.(*ast.ReturnStmt)
+		switch {
+		case returns && n > 1 && isRegistration(body.List[n-2]):
+			offset, insert = lineStart(line(body.List[n-2].End())+1), "\t"+statement+"\n"
+		case returns:
+			offset, insert = lineStart(leadingLine(fset, file, last)), "\t"+statement+"\n\n"
+		case isRegistration(last):
+			offset, insert = lineStart(line(body.Rbrace)), "\t"+statement+"\n"
+		}
+	}
+
+	updated, err := format.Source(slices.Concat(source[:offset], []byte(insert), source[offset:]))
+	if err != nil {
+		return nil, statement, err
+	}
+	return updated, statement, nil
+}
+
+// leadingLine returns the line stmt starts on, including the
+// comments right above it
+func leadingLine(fset *token.FileSet, file *ast.File, stmt ast.Stmt) int {
+	start := fset.Position(stmt.Pos()).Line
+	for i := len(file.Comments) - 1; i >= 0; i-- {
+		group := file.Comments[i]
+		if fset.Position(group.End()).Line == start-1 {
+			start = fset.Position(group.Pos()).Line
+		}
+	}
+	return start
+}
+
+// isRegistration reports whether stmt calls a method registering
+// the routes of a resource, like s.registerUserRoutes(r), checking
+// its error or not
+func isRegistration(stmt ast.Stmt) bool {
+	var expr ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.IfStmt:
+		assign, ok := stmt.Init.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 {
+			return false
+		}
+		expr = assign.Rhs[0]
+	default:
+		return false
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && registrationPattern.MatchString(selector.Sel.Name)
+}
+
+// lastMigration returns the version of the last migration in dir
+func lastMigration(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if v, err := strconv.Atoi(prefix); ok && err == nil && v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// splitWords splits a name in snake, kebab or camel case into
+// its lower case words
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// pluralize returns the plural of an English word
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case len(word) > 1 && strings.HasSuffix(word, "y") && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	default:
+		return word + "s"
+	}
+}
+
+// goName returns the exported Go name made of words
+func goName(words []string) string {
+	var name strings.Builder
+	for _, word := range words {
+		if slices.Contains(initialisms, word) {
+			name.WriteString(strings.ToUpper(word))
+			continue
+		}
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// varName returns the unexported Go name made of words
+func varName(words []string) string {
+	return words[0] + goName(words[1:])
+}
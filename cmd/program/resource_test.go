@@ -0,0 +1,346 @@
+package program
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   string
+		expected Resource
+	}{
+		{
+			name:   "post",
+			fields: "title:string, views:int",
+			expected: Resource{
+				Name: "post", Plural: "posts", GoName: "Post", GoPlural: "Posts", VarName: "post", VarPlural: "posts",
+				File: "post", Table: "posts", Route: "/posts",
+				Fields: []ResourceField{{Name: "title", GoName: "Title", Type: "string"}, {Name: "views", GoName: "Views", Type: "int"}},
+			},
+		},
+		{
+			name:   "BlogPost",
+			fields: "published_at:time",
+			expected: Resource{
+				Name: "blog post", Plural: "blog posts", GoName: "BlogPost", GoPlural: "BlogPosts", VarName: "blogPost", VarPlural: "blogPosts",
+				File: "blog_post", Table: "blog_posts", Route: "/blog-posts",
+				Fields: []ResourceField{{Name: "published_at", GoName: "PublishedAt", Type: "time"}},
+			},
+		},
+		{
+			name:   "api-category",
+			fields: "url:string",
+			expected: Resource{
+				Name: "api category", Plural: "api categories", GoName: "APICategory", GoPlural: "APICategories", VarName: "apiCategory", VarPlural: "apiCategories",
+				File: "api_category", Table: "api_categories", Route: "/api-categories",
+				Fields: []ResourceField{{Name: "url", GoName: "URL", Type: "string"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := ParseResource(tt.name, tt.fields)
+			if err != nil {
+				t.Fatalf("could not parse resource: %v", err)
+			}
+			if !reflect.DeepEqual(*resource, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, *resource)
+			}
+		})
+	}
+}
+
+func TestParseResourceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+	}{
+		{name: "1post", fields: "title:string"},
+		{name: "post", fields: ""},
+		{name: "post", fields: "title"},
+		{name: "post", fields: "title:uuid"},
+		{name: "post", fields: "id:int"},
+		{name: "post", fields: "title:string,Title:string"},
+		{name: "err", fields: "title:string"},
+		{name: "type", fields: "title:string"},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseResource(tt.name, tt.fields); err == nil {
+			t.Errorf("expected resource %s with fields '%s' to be rejected", tt.name, tt.fields)
+		}
+	}
+}
+
+func TestAddRegistration(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name: "before return",
+			source: `package server
+
+func (s *Server) RegisterRoutes() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.HelloWorldHandler)
+
+	// Serve the routes
+	return r
+}
+`,
+			expected: `package server
+
+func (s *Server) RegisterRoutes() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.HelloWorldHandler)
+
+	if err := s.registerPostRoutes(r); err != nil {
+		panic(err)
+	}
+
+	// Serve the routes
+	return r
+}
+`,
+		},
+		{
+			name: "after registrations",
+			source: `package server
+
+func (s *Server) RegisterRoutes() http.Handler {
+	r := chi.NewRouter()
+
+	s.registerTagRoutes(r)
+
+	return r
+}
+`,
+			expected: `package server
+
+func (s *Server) RegisterRoutes() http.Handler {
+	r := chi.NewRouter()
+
+	s.registerTagRoutes(r)
+	if err := s.registerPostRoutes(r); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+`,
+		},
+		{
+			name: "returning an error",
+			source: `package server
+
+func (s *Server) RegisterRoutes() (http.Handler, error) {
+	r := chi.NewRouter()
+
+	if err := s.registerTagRoutes(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+`,
+			expected: `package server
+
+func (s *Server) RegisterRoutes() (http.Handler, error) {
+	r := chi.NewRouter()
+
+	if err := s.registerTagRoutes(r); err != nil {
+		return nil, err
+	}
+	if err := s.registerPostRoutes(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+`,
+		},
+		{
+			name: "without return",
+			source: `package server
+
+func (s *FiberServer) RegisterFiberRoutes() {
+	s.App.Get("/", s.HelloWorldHandler)
+}
+`,
+			expected: `package server
+
+func (s *FiberServer) RegisterFiberRoutes() {
+	s.App.Get("/", s.HelloWorldHandler)
+
+	if err := s.registerPostRoutes(r); err != nil {
+		panic(err)
+	}
+}
+`,
+		},
+		{
+			name: "fiber returning an error",
+			source: `package server
+
+func (s *FiberServer) RegisterFiberRoutes() (err error) {
+	s.App.Get("/", s.HelloWorldHandler)
+
+	return nil
+}
+`,
+			expected: `package server
+
+func (s *FiberServer) RegisterFiberRoutes() (err error) {
+	s.App.Get("/", s.HelloWorldHandler)
+
+	if err := s.registerPostRoutes(r); err != nil {
+		return err
+	}
+
+	return nil
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, statement, err := addRegistration([]byte(tt.source), "s.registerPostRoutes(r)")
+			if err != nil {
+				t.Fatalf("could not add registration: %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, content)
+			}
+			if first, _, _ := strings.Cut(statement, "\n"); !strings.Contains(string(content), first) {
+				t.Errorf("expected the statement added, got %s", statement)
+			}
+
+			if _, _, err := addRegistration(content, "s.registerPostRoutes(r)"); err == nil {
+				t.Errorf("expected a registration to be added once")
+			}
+		})
+	}
+
+	// The statement to add by hand compiles whatever RegisterRoutes returns
+	manual := "if err := s.registerPostRoutes(r); err != nil {\n\tpanic(err)\n}"
+	for _, source := range []string{"package server\n", "package server\n\nfunc (s *Server) RegisterRoutes() http.Handler {"} {
+		if _, statement, err := addRegistration([]byte(source), "s.registerPostRoutes(r)"); err == nil || statement != manual {
+			t.Errorf("expected %q to be rejected with the statement to add by hand, got %v and %q", source, err, statement)
+		}
+	}
+}
+
+func TestPlanResource(t *testing.T) {
+	resource, err := ParseResource("blog-post", "title:string,views:int,score:float64,published:bool,published_at:time")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, framework := range flags.AllowedProjectTypes {
+		for _, driver := range []flags.Database{flags.None, flags.Postgres, flags.Mongo, flags.Redis, flags.Scylla} {
+			t.Run(framework+"/"+string(driver), func(t *testing.T) {
+				projectPath := writeProject(t, flags.Framework(framework), driver)
+				project, err := DetectProject(projectPath)
+				if err != nil {
+					t.Fatalf("could not detect project: %v", err)
+				}
+
+				plan, err := project.PlanResource(projectPath, resource, "")
+				if err != nil {
+					t.Fatalf("could not plan resource: %v", err)
+				}
+				if len(plan.Files) != 3 || plan.Routes == nil {
+					t.Fatalf("expected 3 files and routes.go to be planned, got %d files and routes %v", len(plan.Files), plan.Routes)
+				}
+				for _, file := range append(plan.Files, *plan.Routes) {
+					if _, err := parser.ParseFile(token.NewFileSet(), file.Path, file.Content, parser.AllErrors); err != nil {
+						t.Errorf("could not parse %s: %v", file.Path, err)
+					}
+				}
+				// The statement is indented in routes.go, only its first line is found as is
+				registration, _, _ := strings.Cut(plan.Registration, "\n")
+				if !strings.Contains(string(plan.Routes.Content), registration) {
+					t.Errorf("expected routes.go to contain %s", registration)
+				}
+			})
+		}
+	}
+}
+
+func TestResourcePlanApply(t *testing.T) {
+	resource, err := ParseResource("post", "title:string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, memory := newTestProject(flags.Chi, flags.None)
+	if err := project.CreateMainFile(); err != nil {
+		t.Fatalf("could not create project: %v", err)
+	}
+
+	plan, err := project.PlanResource("/workspace/blueprint", resource, "")
+	if err != nil {
+		t.Fatalf("could not plan resource: %v", err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("could not apply plan: %v", err)
+	}
+
+	for _, file := range append(plan.Files, *plan.Routes) {
+		content, err := memory.ReadFile(path.Join("/workspace/blueprint", file.Path))
+		if err != nil || string(content) != string(file.Content) {
+			t.Errorf("expected %s to be written: %v", file.Path, err)
+		}
+	}
+	if _, err := project.PlanResource("/workspace/blueprint", resource, ""); err == nil {
+		t.Errorf("expected a resource to be generated once")
+	}
+}
+
+func TestPlanResourceMigrations(t *testing.T) {
+	resource, err := ParseResource("post", "title:string")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectPath := writeProject(t, flags.Gin, flags.Sqlite, flags.Migrations)
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatalf("could not detect project: %v", err)
+	}
+
+	plan, err := project.PlanResource(projectPath, resource, "")
+	if err != nil {
+		t.Fatalf("could not plan resource: %v", err)
+	}
+	var migrations []string
+	for _, file := range plan.Files {
+		if path.Ext(file.Path) == ".sql" {
+			migrations = append(migrations, file.Path)
+		}
+	}
+	expected := []string{"migrations/000002_create_posts.up.sql", "migrations/000002_create_posts.down.sql"}
+	if !reflect.DeepEqual(migrations, expected) {
+		t.Errorf("expected migrations %v, got %v", expected, migrations)
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("could not apply plan: %v", err)
+	}
+	if _, err := project.PlanResource(projectPath, resource, ""); err == nil {
+		t.Errorf("expected a resource to be generated once")
+	}
+	if _, err := project.PlanResource(projectPath, resource, flags.Postgres); err == nil {
+		t.Errorf("expected a driver the project does not use to be rejected")
+	}
+}
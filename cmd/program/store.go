@@ -0,0 +1,356 @@
+package program
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	tpl "github.com/melkeydev/go-blueprint/cmd/template"
+	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
+	"github.com/melkeydev/go-blueprint/cmd/template/dbdriver"
+	"github.com/melkeydev/go-blueprint/cmd/template/framework"
+	"gopkg.in/yaml.v3"
+)
+
+// A Store is one of the databases selected for a project. Driver
+// templates are rendered with their Store, which has the fields
+// and methods of the Project, as data
+type Store struct {
+	*Project
+	DBDriver flags.Database
+}
+
+// Stores returns a Store for every driver of the project, in
+// the order they were selected
+func (p *Project) Stores() []Store {
+	var stores []Store
+	for _, driver := range p.DBDriver.Split() {
+		stores = append(stores, Store{Project: p, DBDriver: driver})
+	}
+	return stores
+}
+
+// MultipleDrivers reports whether the project uses several
+// databases, each generated into its own package
+func (p *Project) MultipleDrivers() bool {
+	return len(p.DBDriver.Split()) > 1
+}
+
+// SupportsCompose reports whether a database of the
+// project runs as a service of docker-compose.yml
+func (p *Project) SupportsCompose() bool {
+	return slices.ContainsFunc(p.Stores(), func(s Store) bool { return s.Driver().SupportsCompose() })
+}
+
+// NeedsCGO reports whether a driver of the project needs cgo
+func (p *Project) NeedsCGO() bool {
+	return slices.ContainsFunc(p.Stores(), func(s Store) bool { return s.Driver().NeedsCGO })
+}
+
+// MigratedStores returns the stores of the project the
+// migrations feature applies migrations to, the SQL ones
+func (p *Project) MigratedStores() []Store {
+	var stores []Store
+	for _, store := range p.Stores() {
+		if _, ok := store.Driver().Templater.(MigrationsTemplater); ok {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// ORMStores returns the stores of the project using its ORM
+func (p *Project) ORMStores() []Store {
+	var stores []Store
+	for _, store := range p.Stores() {
+		if _, ok := store.orm(); ok {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// QueriedStores returns the stores of the project
+// the sqlc feature generates typed queries for
+func (p *Project) QueriedStores() []Store {
+	var stores []Store
+	for _, store := range p.Stores() {
+		if _, ok := store.Driver().Templater.(QueriesTemplater); ok {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// orm returns the registered ORM of the project, when it is
+// one and supports the driver of the store
+func (s Store) orm() (RegisteredORM, bool) {
+	for _, o := range orms {
+		if o.Name == s.ORM {
+			_, ok := o.Packages[s.DBDriver]
+			return o, ok
+		}
+	}
+	return RegisteredORM{}, false
+}
+
+// Driver returns the registered driver of the store
+func (s Store) Driver() RegisteredDriver {
+	for _, driver := range drivers {
+		if driver.Name == s.DBDriver {
+			return driver
+		}
+	}
+	return RegisteredDriver{}
+}
+
+// DatabasePackage returns the name of the Go package of the store,
+// database for a single store and the driver name otherwise
+func (s Store) DatabasePackage() string {
+	if !s.MultipleDrivers() {
+		return "database"
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, string(s.DBDriver))
+}
+
+// GoName returns the exported Go name of the store, Postgres for postgres
+func (s Store) GoName() string {
+	name := s.DatabasePackage()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// Path returns the directory of the package of
+// the store, relative to the project directory
+func (s Store) Path() string {
+	if !s.MultipleDrivers() {
+		return internalDatabasePath
+	}
+	return internalDatabasePath + "/" + s.DatabasePackage()
+}
+
+// Dir returns the directory of the files of the store, like its
+// migrations, relative to the project directory holding them for
+// every store
+func (s Store) Dir() string {
+	if !s.MultipleDrivers() {
+		return "."
+	}
+	return s.DatabasePackage()
+}
+
+// SchemaPath returns the path sqlc reads the schema of the store
+// from, its migrations with the migrations feature
+func (s Store) SchemaPath() string {
+	if s.AdvancedOptions[flags.Migrations] {
+		return path.Join(migrationsPath, s.Dir())
+	}
+	return path.Join(schemaPath, s.Dir())
+}
+
+// QueriesPath returns the directory of the SQL queries of the store
+func (s Store) QueriesPath() string {
+	return path.Join(queriesPath, s.Dir())
+}
+
+// EnvPrefix returns the prefix of the environment variables of the
+// store, BLUEPRINT_DB for a single store and BLUEPRINT_POSTGRES for
+// the postgres store of a project with several drivers
+func (s Store) EnvPrefix() string {
+	if !s.MultipleDrivers() {
+		return "BLUEPRINT_DB"
+	}
+	return "BLUEPRINT_" + strings.ToUpper(s.DatabasePackage())
+}
+
+// envFile returns the .env file of the project, the global variables
+// followed by the variables of its stores, in a section for each
+// store when there are several
+func (p *Project) envFile() ([]byte, error) {
+	stores := p.Stores()
+	switch len(stores) {
+	case 0:
+		return execute(".env", tpl.GlobalEnvTemplate(), p)
+	case 1:
+		envBytes := [][]byte{
+			tpl.GlobalEnvTemplate(),
+			p.DBDriverMap[stores[0].DBDriver].templater.Env(),
+		}
+		return execute(".env", bytes.Join(envBytes, []byte("\n")), stores[0])
+	}
+
+	env, err := execute(".env", tpl.GlobalEnvTemplate(), p)
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range stores {
+		header := "\n# " + store.Driver().Title + "\n"
+		section, err := execute(".env", append([]byte(header), p.DBDriverMap[store.DBDriver].templater.Env()...), store)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, section...)
+	}
+	return env, nil
+}
+
+// configFile returns the config.go of the config package, the global
+// settings followed by the settings of every store, each section
+// rendered with its Store
+func (p *Project) configFile() ([]byte, error) {
+	config, err := execute("config.go", framework.ConfigTemplate(), p)
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range p.Stores() {
+		section, err := execute("config.go", p.DBDriverMap[store.DBDriver].templater.Config(), store)
+		if err != nil {
+			return nil, err
+		}
+		config = append(config, section...)
+	}
+	return config, nil
+}
+
+// sqlcFile returns the sqlc.yaml of the project, with an entry
+// generating the typed queries of every SQL store
+func (p *Project) sqlcFile() ([]byte, error) {
+	sqlc, err := execute("sqlc.yaml", dbdriver.SqlcTemplate(), p)
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range p.QueriedStores() {
+		entry, err := execute("sqlc.yaml", p.DBDriverMap[store.DBDriver].templater.(QueriesTemplater).Sqlc(), store)
+		if err != nil {
+			return nil, err
+		}
+		sqlc = append(sqlc, entry...)
+	}
+	return sqlc, nil
+}
+
+// composeFile returns the docker-compose.yml of the project, with the
+// service of every database running in docker-compose, the application
+// with the Docker feature, the collector and Jaeger with the otel
+// feature, and Prometheus with the metrics feature
+func (p *Project) composeFile() ([]byte, error) {
+	var files [][]byte
+	for _, store := range p.Stores() {
+		templateBytes := advanced.DockerCompose()
+		if docker, ok := p.DockerMap[store.DBDriver]; ok {
+			templateBytes = docker.templater.Docker()
+		} else if !p.AdvancedOptions[flags.Docker] {
+			continue
+		}
+
+		file, err := execute("docker-compose.yml", templateBytes, store)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	if len(files) == 0 && p.AdvancedOptions[flags.Docker] {
+		file, err := execute("docker-compose.yml", advanced.DockerCompose(), p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if p.AdvancedOptions[flags.Otel] {
+		file, err := execute("docker-compose.yml", advanced.TelemetryCompose(), p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if p.AdvancedOptions[flags.Metrics] {
+		file, err := execute("docker-compose.yml", advanced.MetricsCompose(), p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 1 {
+		return files[0], nil
+	}
+	return mergeCompose(files)
+}
+
+// mergeCompose merges docker-compose.yml files into one. Keys missing
+// from the first file are added from the next ones, like the services
+// of the databases, and the services declared by several files, like
+// the application, are merged key by key
+func mergeCompose(files [][]byte) ([]byte, error) {
+	var merged yaml.Node
+	for i, file := range files {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(file, &doc); err != nil {
+			return nil, fmt.Errorf("invalid docker-compose.yml of store %d: %w", i+1, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		if len(merged.Content) == 0 {
+			merged = doc
+			continue
+		}
+		mergeNodes(merged.Content[0], doc.Content[0])
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&merged); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeNodes adds the keys of the src mapping missing from dst, and
+// the items of the src sequence missing from dst
+func mergeNodes(dst, src *yaml.Node) {
+	switch {
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, value := src.Content[i], src.Content[i+1]
+			if existing := mappingValue(dst, key.Value); existing != nil {
+				mergeNodes(existing, value)
+			} else {
+				dst.Content = append(dst.Content, key, value)
+			}
+		}
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		for _, item := range src.Content {
+			duplicate := item.Kind == yaml.ScalarNode && slices.ContainsFunc(dst.Content, func(n *yaml.Node) bool {
+				return n.Kind == yaml.ScalarNode && n.Value == item.Value
+			})
+			if !duplicate {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+	case dst.Tag == "!!null":
+		*dst = *src
+	}
+}
+
+// mappingValue returns the value of key in the mapping, nil if it is missing
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package program
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/melkeydev/go-blueprint/cmd/diff"
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/utils"
+)
+
+// UpgradeStatus tells how an upgrade changes a file
+type UpgradeStatus int
+
+const (
+	// UpgradeCreated files are new in this version of Blueprint
+	UpgradeCreated UpgradeStatus = iota
+	// UpgradeUpdated files were never modified and are replaced
+	UpgradeUpdated
+	// UpgradeMerged files combine local and template changes
+	UpgradeMerged
+	// UpgradeConflict files hold conflict markers to resolve
+	UpgradeConflict
+)
+
+func (s UpgradeStatus) String() string {
+	switch s {
+	case UpgradeCreated:
+		return "created"
+	case UpgradeUpdated:
+		return "updated"
+	case UpgradeMerged:
+		return "merged"
+	default:
+		return "conflict"
+	}
+}
+
+// An UpgradedFile is a file of the project rewritten by an upgrade
+type UpgradedFile struct {
+	Path      string // Path relative to the project directory
+	Content   []byte
+	Status    UpgradeStatus
+	Conflicts int
+}
+
+// An UpgradePlan contains the changes needed to bring an existing
+// project to the templates of the running version of Blueprint
+type UpgradePlan struct {
+	ProjectPath string
+	// FS is the file system holding the project. The dependencies
+	// are only resolved on the operating system
+	FS          filesystem.FileSystem
+	FromVersion string
+	ToVersion   string
+	Files       []UpgradedFile
+	// Removed lists the generated files deleted from the
+	// project, which the upgrade does not bring back
+	Removed []string
+
+	manifest *Manifest
+	history  *history
+	// generated holds the files rendered by this version,
+	// the base of the merges of the next upgrade
+	generated map[string][]byte
+}
+
+// PlanUpgrade renders the project again with the options it was
+// generated with, and merges the result with its current files. The
+// files as originally generated are the base of the merges: they are
+// looked up in git from the checksums of the manifest. Files without
+// a known base are merged with every difference left as a conflict
+func (p *Project) PlanUpgrade(projectPath string) (*UpgradePlan, error) {
+	target, targetRoot, err := p.renderInMemory(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not render the project: %w", err)
+	}
+
+	manifest := p.manifest
+	if manifest == nil {
+		manifest, err = p.reconstructManifest(projectPath, target, targetRoot)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Version = ""
+	}
+
+	plan := &UpgradePlan{
+		ProjectPath: projectPath,
+		FS:          p.FS,
+		FromVersion: manifest.Version,
+		ToVersion:   p.BlueprintVersion,
+		manifest:    manifest,
+		history:     openHistory(projectPath),
+		generated:   make(map[string][]byte),
+	}
+	labels := diff.Labels{Ours: "current", Theirs: "go-blueprint " + p.BlueprintVersion}
+
+	for _, name := range target.Paths() {
+		rel := strings.TrimPrefix(name, targetRoot+"/")
+		if rel == "go.mod" || rel == ManifestFile {
+			continue
+		}
+
+		content, err := target.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		plan.generated[rel] = content
+
+		checksum, generated := manifest.Files[rel]
+		current, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, os.ErrNotExist) && generated:
+			plan.Removed = append(plan.Removed, rel)
+			continue
+		case errors.Is(err, os.ErrNotExist):
+			plan.Files = append(plan.Files, UpgradedFile{Path: rel, Content: content, Status: UpgradeCreated})
+			continue
+		case err != nil:
+			return nil, err
+		case sameContent(rel, current, content):
+			continue
+		case manifest.Unmodified(rel, current):
+			plan.Files = append(plan.Files, UpgradedFile{Path: rel, Content: content, Status: UpgradeUpdated})
+			continue
+		}
+
+		var merged []string
+		var conflicts int
+		if base, ok := plan.history.base(rel, checksum); ok {
+			if sameContent(rel, base, content) {
+				// The template did not change, keep the local changes
+				continue
+			}
+			merged, conflicts = diff.Merge(diff.SplitLines(base), diff.SplitLines(current), diff.SplitLines(content), labels)
+		} else {
+			merged, conflicts = diff.MergeWithoutBase(diff.SplitLines(current), diff.SplitLines(content), labels)
+		}
+
+		file := UpgradedFile{Path: rel, Status: UpgradeMerged, Conflicts: conflicts}
+		if conflicts > 0 {
+			file.Status = UpgradeConflict
+		}
+		if len(merged) > 0 {
+			file.Content = []byte(strings.Join(merged, "\n") + "\n")
+		}
+		if sameContent(rel, current, file.Content) {
+			continue
+		}
+		plan.Files = append(plan.Files, file)
+	}
+
+	return plan, nil
+}
+
+// Conflicts returns the number of conflicts left in the upgraded files
+func (plan *UpgradePlan) Conflicts() int {
+	conflicts := 0
+	for _, file := range plan.Files {
+		conflicts += file.Conflicts
+	}
+	return conflicts
+}
+
+// Apply writes the upgraded files into the project directory of FS
+// and updates its manifest. In git repositories, the generated files are
+// recorded under BaseRef to serve as the base of the next upgrade.
+// Dependencies are only resolved once no conflict is left
+func (plan *UpgradePlan) Apply() error {
+	for _, file := range plan.Files {
+		if err := writeProjectFile(plan.FS, plan.ProjectPath, file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+
+	plan.manifest.Version = plan.ToVersion
+	for rel, content := range plan.generated {
+		if _, err := plan.FS.Stat(filepath.Join(plan.ProjectPath, filepath.FromSlash(rel))); err == nil {
+			plan.manifest.Files[rel] = Checksum(content)
+		}
+	}
+	content, err := plan.manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := writeProjectFile(plan.FS, plan.ProjectPath, ManifestFile, content); err != nil {
+		return err
+	}
+
+	if plan.history != nil {
+		if err := plan.history.storeBase(plan.generated, "go-blueprint "+plan.ToVersion); err != nil {
+			return fmt.Errorf("could not record the generated files: %w", err)
+		}
+	}
+
+	// Conflict markers break the build, "go mod tidy"
+	// has to wait for the conflicts to be resolved
+	if len(plan.Files) == 0 || plan.Conflicts() > 0 || !onDisk(plan.FS) {
+		return nil
+	}
+
+	return utils.GoTidy(plan.ProjectPath)
+}
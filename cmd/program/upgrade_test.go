@@ -0,0 +1,121 @@
+package program
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/melkeydev/go-blueprint/cmd/filesystem"
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func TestUpgradePlanApply(t *testing.T) {
+	memory := filesystem.NewMemory()
+	routes := []byte("package server\n")
+	plan := &UpgradePlan{
+		ProjectPath: "/workspace/blueprint",
+		FS:          memory,
+		ToVersion:   "v1.2.3",
+		Files:       []UpgradedFile{{Path: "internal/server/routes.go", Content: routes, Status: UpgradeMerged}},
+		manifest:    &Manifest{Version: "v1.0.0", Files: map[string]string{}},
+		// Deleted from the project, the removed file stays out of the manifest
+		generated: map[string][]byte{"internal/server/routes.go": routes, "Makefile": []byte("all:\n")},
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("could not apply plan: %v", err)
+	}
+
+	content, err := memory.ReadFile("/workspace/blueprint/internal/server/routes.go")
+	if err != nil || !bytes.Equal(content, routes) {
+		t.Errorf("expected the upgraded file to be written, got %q: %v", content, err)
+	}
+	manifest, err := ReadManifest(memory, "/workspace/blueprint")
+	if err != nil {
+		t.Fatalf("could not read manifest: %v", err)
+	}
+	if manifest.Version != "v1.2.3" || !manifest.Unmodified("internal/server/routes.go", routes) {
+		t.Errorf("expected the manifest of v1.2.3 with the checksum of routes.go, got %+v", manifest)
+	}
+	if _, ok := manifest.Files["Makefile"]; ok {
+		t.Errorf("did not expect the removed Makefile in the manifest")
+	}
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	projectPath := writeProject(t, flags.Chi, flags.None)
+	routesPath := filepath.Join(projectPath, "internal", "server", "routes.go")
+	generated := mustReadFile(t, routesPath)
+
+	// Pretend an older version of the template generated
+	// a different message, and record it in the manifest
+	old := bytes.Replace(generated, []byte(`"Hello World"`), []byte(`"Hello"`), 1)
+	if err := os.WriteFile(routesPath, old, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(filesystem.OS{}, projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.Files["internal/server/routes.go"] = Checksum(old)
+	content, err := manifest.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, ManifestFile), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, projectPath, "init", "--quiet")
+	runGit(t, projectPath, "add", ".")
+	runGit(t, projectPath, "commit", "--quiet", "-m", "Initial commit")
+
+	local := bytes.Replace(old, []byte("\tr.Get(\"/\", s.HelloWorldHandler)\n"), []byte("\tr.Get(\"/\", s.HelloWorldHandler)\n\tr.Get(\"/ping\", s.HelloWorldHandler)\n"), 1)
+	if err := os.WriteFile(routesPath, local, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, projectPath, "commit", "--quiet", "-am", "Add ping route")
+
+	project, err := DetectProject(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	project.BlueprintVersion = "v1.0.0"
+	plan, err := project.PlanUpgrade(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.Files) != 1 || plan.Files[0].Path != "internal/server/routes.go" {
+		t.Fatalf("expected only routes.go to be upgraded, got %+v", plan.Files)
+	}
+	file := plan.Files[0]
+	if file.Status != UpgradeMerged || plan.Conflicts() != 0 {
+		t.Errorf("expected a clean merge, got %s with %d conflicts", file.Status, plan.Conflicts())
+	}
+	if !bytes.Contains(file.Content, []byte(`"/ping"`)) || !bytes.Contains(file.Content, []byte(`"Hello World"`)) {
+		t.Errorf("expected both the local and the template changes, got:\n%s", file.Content)
+	}
+
+	// Once recorded, the generated files are the base of the next upgrade
+	if err := plan.history.storeBase(plan.generated, "go-blueprint v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	base, ok := plan.history.base("internal/server/routes.go", Checksum(generated))
+	if !ok || !bytes.Equal(base, generated) {
+		t.Errorf("expected %s to hold the generated routes.go", BaseRef)
+	}
+}
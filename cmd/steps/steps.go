@@ -2,7 +2,10 @@
 // each step of the CLI
 package steps
 
-import "github.com/melkeydev/go-blueprint/cmd/flags"
+import (
+	"github.com/melkeydev/go-blueprint/cmd/flags"
+	"github.com/melkeydev/go-blueprint/cmd/program"
+)
 
 // A StepSchema contains the data that is used
 // for an individual step of the CLI
@@ -25,72 +28,26 @@ type Item struct {
 }
 
 // InitSteps initializes and returns the *Steps to be used in the CLI program
-func InitSteps(projectType flags.Framework, databaseType flags.Database) *Steps {
+func InitSteps(projectType flags.Framework, databaseType flags.Database, ormType flags.ORM) *Steps {
 	steps := &Steps{
 		map[string]StepSchema{
 			"framework": {
 				StepName: "Go Project Framework",
-				Options: []Item{
-					{
-						Title: "Standard-library",
-						Desc:  "The built-in Go standard library HTTP package",
-					},
-					{
-						Title: "Chi",
-						Desc:  "A lightweight, idiomatic and composable router for building Go HTTP services",
-					},
-					{
-						Title: "Gin",
-						Desc:  "Features a martini-like API with performance that is up to 40 times faster thanks to httprouter",
-					},
-					{
-						Title: "Fiber",
-						Desc:  "An Express inspired web framework built on top of Fasthttp",
-					},
-					{
-						Title: "Gorilla/Mux",
-						Desc:  "Package gorilla/mux implements a request router and dispatcher for matching incoming requests to their respective handler",
-					},
-					{
-						Title: "HttpRouter",
-						Desc:  "HttpRouter is a lightweight high performance HTTP request router for Go",
-					},
-					{
-						Title: "Echo",
-						Desc:  "High performance, extensible, minimalist Go web framework",
-					},
-				},
-				Headers: "What framework do you want to use in your Go project?",
-				Field:   projectType.String(),
+				Options:  frameworkItems(),
+				Headers:  "What framework do you want to use in your Go project?",
+				Field:    projectType.String(),
 			},
 			"driver": {
 				StepName: "Go Project Database Driver",
-				Options: []Item{
-					{
-						Title: "Mysql",
-						Desc:  "MySQL-Driver for Go's database/sql package",
-					},
-					{
-						Title: "Postgres",
-						Desc:  "Go postgres driver for Go's database/sql package"},
-					{
-						Title: "Sqlite",
-						Desc:  "sqlite3 driver conforming to the built-in database/sql interface"},
-					{
-						Title: "Mongo",
-						Desc:  "The MongoDB supported driver for Go."},
-					{
-						Title: "Redis",
-						Desc:  "Redis driver for Go."},
-					{
-						Title: "Scylla",
-						Desc:  "ScyllaDB Enhanced driver from GoCQL."},
-					{
-						Title: "None",
-						Desc:  "Choose this option if you don't wish to install a specific database driver."},
-				},
-				Headers: "What database driver do you want to use in your Go project?",
-				Field:   databaseType.String(),
+				Options:  driverItems(),
+				Headers:  "What database driver do you want to use in your Go project?",
+				Field:    databaseType.String(),
+			},
+			"orm": {
+				StepName: "Go Project ORM",
+				Options:  ormItems(),
+				Headers:  "What do you want to query your SQL database with?",
+				Field:    ormType.String(),
 			},
 			"advanced": {
 				StepName: "Advanced Features",
@@ -126,6 +83,51 @@ func InitSteps(projectType flags.Framework, databaseType flags.Database) *Steps
 						Title: "Docker",
 						Desc:  "Dockerfile and docker-compose generic configuration for go project",
 					},
+					{
+						Flag:  "Migrations",
+						Title: "Database migrations",
+						Desc:  "SQL migrations embedded into the application, with a runner and Makefile targets. Requires a Postgres, MySQL or SQLite driver",
+					},
+					{
+						Flag:  "Sqlc",
+						Title: "Typed SQL queries",
+						Desc:  "Go code generated by sqlc from SQL queries, exposed by the database service. Requires a Postgres, MySQL or SQLite driver",
+					},
+					{
+						Flag:  "Grpc",
+						Title: "gRPC",
+						Desc:  "A gRPC server alongside the HTTP one, with a sample protobuf service, buf configuration and health checking",
+					},
+					{
+						Flag:  "Graphql",
+						Title: "GraphQL",
+						Desc:  "A GraphQL endpoint and playground generated by gqlgen from a starter schema, with resolvers using the database service",
+					},
+					{
+						Flag:  "Auth",
+						Title: "Authentication",
+						Desc:  "Register, login and refresh endpoints issuing JWTs, with middleware protecting routes. Users are stored in the SQL database, or in memory",
+					},
+					{
+						Flag:  "Otel",
+						Title: "OpenTelemetry",
+						Desc:  "Traces, metrics and logs exported with OTLP, from the HTTP routes and the database clients, with a local collector and Jaeger in docker-compose",
+					},
+					{
+						Flag:  "Metrics",
+						Title: "Prometheus metrics",
+						Desc:  "A /metrics endpoint with the request and connection pool metrics, pprof on a separate admin listener, and Prometheus in docker-compose",
+					},
+					{
+						Flag:  "Kubernetes",
+						Title: "Kubernetes",
+						Desc:  "Manifests in deploy/kubernetes: a Deployment probed on /livez and /readyz, a Service, and a ConfigMap and Secret built from .env",
+					},
+					{
+						Flag:  "Helm",
+						Title: "Helm chart",
+						Desc:  "A Helm chart in deploy/helm installing the resources of the Kubernetes manifests, which it selects as well",
+					},
 				},
 			},
 			"git": {
@@ -151,3 +153,51 @@ func InitSteps(projectType flags.Framework, databaseType flags.Database) *Steps
 
 	return steps
 }
+
+// frameworkItems returns an option for every framework
+// registered with program.Register
+func frameworkItems() []Item {
+	var items []Item
+	for _, framework := range program.Frameworks() {
+		items = append(items, Item{
+			Title: framework.Title,
+			Desc:  framework.Description,
+		})
+	}
+
+	return items
+}
+
+// driverItems returns an option for every driver registered
+// with program.RegisterDriver, followed by none
+func driverItems() []Item {
+	var items []Item
+	for _, driver := range program.Drivers() {
+		items = append(items, Item{
+			Title: driver.Title,
+			Desc:  driver.Description,
+		})
+	}
+
+	return append(items, Item{
+		Title: "None",
+		Desc:  "Choose this option if you don't wish to install a specific database driver.",
+	})
+}
+
+// ormItems returns an option for every ORM registered with
+// program.RegisterORM, followed by none for database/sql
+func ormItems() []Item {
+	var items []Item
+	for _, orm := range program.ORMs() {
+		items = append(items, Item{
+			Title: orm.Title,
+			Desc:  orm.Description,
+		})
+	}
+
+	return append(items, Item{
+		Title: "None",
+		Desc:  "Query the database with the database/sql package of the standard library.",
+	})
+}
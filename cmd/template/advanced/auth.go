@@ -0,0 +1,79 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/auth/auth.go.tmpl
+var authTemplate []byte
+
+//go:embed files/auth/token.go.tmpl
+var authTokenTemplate []byte
+
+//go:embed files/auth/password.go.tmpl
+var authPasswordTemplate []byte
+
+//go:embed files/auth/memory.go.tmpl
+var authMemoryTemplate []byte
+
+//go:embed files/auth/auth_test.go.tmpl
+var authTestTemplate []byte
+
+//go:embed files/auth/server.go.tmpl
+var authServerTemplate []byte
+
+//go:embed files/auth/users.go.tmpl
+var usersTemplate []byte
+
+//go:embed files/auth/users.blocks.tmpl
+var usersBlocksTemplate []byte
+
+//go:embed files/auth/users.up.sql.tmpl
+var usersUpMigrationTemplate []byte
+
+//go:embed files/auth/users.down.sql.tmpl
+var usersDownMigrationTemplate []byte
+
+func Auth() []byte {
+	return template.Overlay("advanced/files/auth/auth.go.tmpl", authTemplate)
+}
+
+func AuthToken() []byte {
+	return template.Overlay("advanced/files/auth/token.go.tmpl", authTokenTemplate)
+}
+
+func AuthPassword() []byte {
+	return template.Overlay("advanced/files/auth/password.go.tmpl", authPasswordTemplate)
+}
+
+func AuthMemory() []byte {
+	return template.Overlay("advanced/files/auth/memory.go.tmpl", authMemoryTemplate)
+}
+
+func AuthTest() []byte {
+	return template.Overlay("advanced/files/auth/auth_test.go.tmpl", authTestTemplate)
+}
+
+func AuthServer() []byte {
+	return template.Overlay("advanced/files/auth/server.go.tmpl", authServerTemplate)
+}
+
+func Users() []byte {
+	return template.Overlay("advanced/files/auth/users.go.tmpl", usersTemplate)
+}
+
+// UsersBlocks returns the blocks shared by the store
+// of the users and their migration
+func UsersBlocks() []byte {
+	return template.Overlay("advanced/files/auth/users.blocks.tmpl", usersBlocksTemplate)
+}
+
+func UsersUpMigration() []byte {
+	return template.Overlay("advanced/files/auth/users.up.sql.tmpl", usersUpMigrationTemplate)
+}
+
+func UsersDownMigration() []byte {
+	return template.Overlay("advanced/files/auth/users.down.sql.tmpl", usersDownMigrationTemplate)
+}
@@ -2,6 +2,8 @@ package advanced
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 //go:embed files/docker/dockerfile.tmpl
@@ -11,9 +13,9 @@ var dockerfileTemplate []byte
 var dockerComposeTemplate []byte
 
 func Dockerfile() []byte {
-	return dockerfileTemplate
+	return template.Overlay("advanced/files/docker/dockerfile.tmpl", dockerfileTemplate)
 }
 
 func DockerCompose() []byte {
-	return dockerComposeTemplate
+	return template.Overlay("advanced/files/docker/docker_compose.yml.tmpl", dockerComposeTemplate)
 }
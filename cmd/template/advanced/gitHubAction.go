@@ -2,6 +2,8 @@ package advanced
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 //go:embed files/workflow/github/github_action_goreleaser.yml.tmpl
@@ -14,13 +16,13 @@ var gitHubActionTestTemplate []byte
 var gitHubActionConfigTemplate []byte
 
 func Releaser() []byte {
-	return gitHubActionBuildTemplate
+	return template.Overlay("advanced/files/workflow/github/github_action_goreleaser.yml.tmpl", gitHubActionBuildTemplate)
 }
 
 func Test() []byte {
-	return gitHubActionTestTemplate
+	return template.Overlay("advanced/files/workflow/github/github_action_gotest.yml.tmpl", gitHubActionTestTemplate)
 }
 
 func ReleaserConfig() []byte {
-	return gitHubActionConfigTemplate
+	return template.Overlay("advanced/files/workflow/github/github_action_releaser_config.yml.tmpl", gitHubActionConfigTemplate)
 }
@@ -0,0 +1,63 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/graphql/gqlgen.yml.tmpl
+var gqlgenTemplate []byte
+
+//go:embed files/graphql/schema.graphqls.tmpl
+var graphqlSchemaTemplate []byte
+
+//go:embed files/graphql/resolver.go.tmpl
+var graphqlResolverTemplate []byte
+
+//go:embed files/graphql/schema.resolvers.go.tmpl
+var graphqlSchemaResolversTemplate []byte
+
+//go:embed files/graphql/health.go.tmpl
+var graphqlHealthTemplate []byte
+
+//go:embed files/graphql/tools.go.tmpl
+var graphqlToolsTemplate []byte
+
+//go:embed files/graphql/server_graphql.go.tmpl
+var graphqlServerTemplate []byte
+
+//go:embed files/graphql/server_graphql_test.go.tmpl
+var graphqlServerTestTemplate []byte
+
+func Gqlgen() []byte {
+	return template.Overlay("advanced/files/graphql/gqlgen.yml.tmpl", gqlgenTemplate)
+}
+
+func GraphqlSchema() []byte {
+	return template.Overlay("advanced/files/graphql/schema.graphqls.tmpl", graphqlSchemaTemplate)
+}
+
+func GraphqlResolver() []byte {
+	return template.Overlay("advanced/files/graphql/resolver.go.tmpl", graphqlResolverTemplate)
+}
+
+func GraphqlSchemaResolvers() []byte {
+	return template.Overlay("advanced/files/graphql/schema.resolvers.go.tmpl", graphqlSchemaResolversTemplate)
+}
+
+func GraphqlHealth() []byte {
+	return template.Overlay("advanced/files/graphql/health.go.tmpl", graphqlHealthTemplate)
+}
+
+func GraphqlTools() []byte {
+	return template.Overlay("advanced/files/graphql/tools.go.tmpl", graphqlToolsTemplate)
+}
+
+func GraphqlServer() []byte {
+	return template.Overlay("advanced/files/graphql/server_graphql.go.tmpl", graphqlServerTemplate)
+}
+
+func GraphqlServerTest() []byte {
+	return template.Overlay("advanced/files/graphql/server_graphql_test.go.tmpl", graphqlServerTestTemplate)
+}
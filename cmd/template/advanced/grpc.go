@@ -0,0 +1,70 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/grpc/hello.proto.tmpl
+var grpcProtoTemplate []byte
+
+//go:embed files/grpc/buf.yaml.tmpl
+var bufTemplate []byte
+
+//go:embed files/grpc/buf.gen.yaml.tmpl
+var bufGenTemplate []byte
+
+//go:embed files/grpc/hello.pb.go.tmpl
+var grpcMessagesTemplate []byte
+
+//go:embed files/grpc/hello_grpc.pb.go.tmpl
+var grpcStubsTemplate []byte
+
+//go:embed files/grpc/server.go.tmpl
+var grpcServerTemplate []byte
+
+//go:embed files/grpc/hello.go.tmpl
+var grpcHelloTemplate []byte
+
+//go:embed files/grpc/health.go.tmpl
+var grpcHealthTemplate []byte
+
+//go:embed files/grpc/server_test.go.tmpl
+var grpcServerTestTemplate []byte
+
+func GrpcProto() []byte {
+	return template.Overlay("advanced/files/grpc/hello.proto.tmpl", grpcProtoTemplate)
+}
+
+func Buf() []byte {
+	return template.Overlay("advanced/files/grpc/buf.yaml.tmpl", bufTemplate)
+}
+
+func BufGen() []byte {
+	return template.Overlay("advanced/files/grpc/buf.gen.yaml.tmpl", bufGenTemplate)
+}
+
+func GrpcMessages() []byte {
+	return template.Overlay("advanced/files/grpc/hello.pb.go.tmpl", grpcMessagesTemplate)
+}
+
+func GrpcStubs() []byte {
+	return template.Overlay("advanced/files/grpc/hello_grpc.pb.go.tmpl", grpcStubsTemplate)
+}
+
+func GrpcServer() []byte {
+	return template.Overlay("advanced/files/grpc/server.go.tmpl", grpcServerTemplate)
+}
+
+func GrpcHello() []byte {
+	return template.Overlay("advanced/files/grpc/hello.go.tmpl", grpcHelloTemplate)
+}
+
+func GrpcHealth() []byte {
+	return template.Overlay("advanced/files/grpc/health.go.tmpl", grpcHealthTemplate)
+}
+
+func GrpcServerTest() []byte {
+	return template.Overlay("advanced/files/grpc/server_test.go.tmpl", grpcServerTestTemplate)
+}
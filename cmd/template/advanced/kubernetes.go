@@ -0,0 +1,101 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/kubernetes/kustomization.yaml.tmpl
+var kustomizationTemplate []byte
+
+//go:embed files/kubernetes/configmap.yaml.tmpl
+var kubernetesConfigMapTemplate []byte
+
+//go:embed files/kubernetes/secret.yaml.tmpl
+var kubernetesSecretTemplate []byte
+
+//go:embed files/kubernetes/deployment.yaml.tmpl
+var kubernetesDeploymentTemplate []byte
+
+//go:embed files/kubernetes/service.yaml.tmpl
+var kubernetesServiceTemplate []byte
+
+//go:embed files/helm/Chart.yaml.tmpl
+var helmChartTemplate []byte
+
+//go:embed files/helm/values.yaml.tmpl
+var helmValuesTemplate []byte
+
+// The templates of the Helm chart are rendered by Helm when the chart
+// is installed, and copied into the project as they are
+
+//go:embed files/helm/templates/_helpers.tpl
+var helmHelpersTemplate []byte
+
+//go:embed files/helm/templates/configmap.yaml
+var helmConfigMapTemplate []byte
+
+//go:embed files/helm/templates/secret.yaml
+var helmSecretTemplate []byte
+
+//go:embed files/helm/templates/deployment.yaml
+var helmDeploymentTemplate []byte
+
+//go:embed files/helm/templates/service.yaml
+var helmServiceTemplate []byte
+
+//go:embed files/helm/templates/NOTES.txt
+var helmNotesTemplate []byte
+
+func Kustomization() []byte {
+	return template.Overlay("advanced/files/kubernetes/kustomization.yaml.tmpl", kustomizationTemplate)
+}
+
+func KubernetesConfigMap() []byte {
+	return template.Overlay("advanced/files/kubernetes/configmap.yaml.tmpl", kubernetesConfigMapTemplate)
+}
+
+func KubernetesSecret() []byte {
+	return template.Overlay("advanced/files/kubernetes/secret.yaml.tmpl", kubernetesSecretTemplate)
+}
+
+func KubernetesDeployment() []byte {
+	return template.Overlay("advanced/files/kubernetes/deployment.yaml.tmpl", kubernetesDeploymentTemplate)
+}
+
+func KubernetesService() []byte {
+	return template.Overlay("advanced/files/kubernetes/service.yaml.tmpl", kubernetesServiceTemplate)
+}
+
+func HelmChart() []byte {
+	return template.Overlay("advanced/files/helm/Chart.yaml.tmpl", helmChartTemplate)
+}
+
+func HelmValues() []byte {
+	return template.Overlay("advanced/files/helm/values.yaml.tmpl", helmValuesTemplate)
+}
+
+func HelmHelpers() []byte {
+	return template.Overlay("advanced/files/helm/templates/_helpers.tpl", helmHelpersTemplate)
+}
+
+func HelmConfigMap() []byte {
+	return template.Overlay("advanced/files/helm/templates/configmap.yaml", helmConfigMapTemplate)
+}
+
+func HelmSecret() []byte {
+	return template.Overlay("advanced/files/helm/templates/secret.yaml", helmSecretTemplate)
+}
+
+func HelmDeployment() []byte {
+	return template.Overlay("advanced/files/helm/templates/deployment.yaml", helmDeploymentTemplate)
+}
+
+func HelmService() []byte {
+	return template.Overlay("advanced/files/helm/templates/service.yaml", helmServiceTemplate)
+}
+
+func HelmNotes() []byte {
+	return template.Overlay("advanced/files/helm/templates/NOTES.txt", helmNotesTemplate)
+}
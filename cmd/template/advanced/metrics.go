@@ -0,0 +1,44 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/metrics/metrics.go.tmpl
+var metricsTemplate []byte
+
+//go:embed files/metrics/admin.go.tmpl
+var metricsAdminTemplate []byte
+
+//go:embed files/metrics/metrics_test.go.tmpl
+var metricsTestTemplate []byte
+
+//go:embed files/metrics/prometheus.yml.tmpl
+var prometheusTemplate []byte
+
+//go:embed files/metrics/docker_compose.yml.tmpl
+var metricsComposeTemplate []byte
+
+func Metrics() []byte {
+	return template.Overlay("advanced/files/metrics/metrics.go.tmpl", metricsTemplate)
+}
+
+func MetricsAdmin() []byte {
+	return template.Overlay("advanced/files/metrics/admin.go.tmpl", metricsAdminTemplate)
+}
+
+func MetricsTest() []byte {
+	return template.Overlay("advanced/files/metrics/metrics_test.go.tmpl", metricsTestTemplate)
+}
+
+func Prometheus() []byte {
+	return template.Overlay("advanced/files/metrics/prometheus.yml.tmpl", prometheusTemplate)
+}
+
+// MetricsCompose returns the docker-compose.yml service of
+// Prometheus, merged into the services of the project
+func MetricsCompose() []byte {
+	return template.Overlay("advanced/files/metrics/docker_compose.yml.tmpl", metricsComposeTemplate)
+}
@@ -0,0 +1,37 @@
+package advanced
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/otel/telemetry.go.tmpl
+var telemetryTemplate []byte
+
+//go:embed files/otel/telemetry_test.go.tmpl
+var telemetryTestTemplate []byte
+
+//go:embed files/otel/otel_collector.yaml.tmpl
+var otelCollectorTemplate []byte
+
+//go:embed files/otel/docker_compose.yml.tmpl
+var telemetryComposeTemplate []byte
+
+func Telemetry() []byte {
+	return template.Overlay("advanced/files/otel/telemetry.go.tmpl", telemetryTemplate)
+}
+
+func TelemetryTest() []byte {
+	return template.Overlay("advanced/files/otel/telemetry_test.go.tmpl", telemetryTestTemplate)
+}
+
+func OtelCollector() []byte {
+	return template.Overlay("advanced/files/otel/otel_collector.yaml.tmpl", otelCollectorTemplate)
+}
+
+// TelemetryCompose returns the docker-compose.yml services of the
+// collector and Jaeger, merged into the services of the project
+func TelemetryCompose() []byte {
+	return template.Overlay("advanced/files/otel/docker_compose.yml.tmpl", telemetryComposeTemplate)
+}
@@ -2,6 +2,8 @@ package advanced
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 //go:embed files/htmx/hello.templ.tmpl
@@ -80,101 +82,101 @@ var fiberHtmxTemplImports []byte
 var fiberWebsocketTemplImports []byte
 
 func EchoHtmxTemplRoutesTemplate() []byte {
-	return echoHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/echo.tmpl", echoHtmxTemplRoutes)
 }
 
 func GorillaHtmxTemplRoutesTemplate() []byte {
-	return gorillaHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/gorilla.tmpl", gorillaHtmxTemplRoutes)
 }
 
 func ChiHtmxTemplRoutesTemplate() []byte {
-	return chiHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/chi.tmpl", chiHtmxTemplRoutes)
 }
 
 func GinHtmxTemplRoutesTemplate() []byte {
-	return ginHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/gin.tmpl", ginHtmxTemplRoutes)
 }
 
 func HttpRouterHtmxTemplRoutesTemplate() []byte {
-	return httpRouterHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/http_router.tmpl", httpRouterHtmxTemplRoutes)
 }
 
 func StdLibHtmxTemplRoutesTemplate() []byte {
-	return stdLibHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/standard_library.tmpl", stdLibHtmxTemplRoutes)
 }
 
 func StdLibHtmxTemplImportsTemplate() []byte {
-	return stdLibHtmxTemplImports
+	return template.Overlay("advanced/files/htmx/imports/standard_library.tmpl", stdLibHtmxTemplImports)
 }
 
 func StdLibWebsocketTemplImportsTemplate() []byte {
-	return stdLibWebsocketImports
+	return template.Overlay("advanced/files/websocket/imports/standard_library.tmpl", stdLibWebsocketImports)
 }
 
 func HelloTemplTemplate() []byte {
-	return helloTemplTemplate
+	return template.Overlay("advanced/files/htmx/hello.templ.tmpl", helloTemplTemplate)
 }
 
 func BaseTemplTemplate() []byte {
-	return baseTemplTemplate
+	return template.Overlay("advanced/files/htmx/base.templ.tmpl", baseTemplTemplate)
 }
 
 func ReactTailwindAppfile() []byte {
-	return reactTailwindAppFile
+	return template.Overlay("advanced/files/react/tailwind/app.tsx.tmpl", reactTailwindAppFile)
 }
 
 func ReactAppfile() []byte {
-	return reactAppFile
+	return template.Overlay("advanced/files/react/app.tsx.tmpl", reactAppFile)
 }
 
 func InputCssTemplateReact() []byte {
-	return inputCssTemplateReact
+	return template.Overlay("advanced/files/react/tailwind/index.css.tmpl", inputCssTemplateReact)
 }
 
 func ViteTailwindConfigFile() []byte {
-	return viteTailwindConfigFile
+	return template.Overlay("advanced/files/react/tailwind/vite.config.ts.tmpl", viteTailwindConfigFile)
 }
 
 func InputCssTemplate() []byte {
-	return inputCssTemplate
+	return template.Overlay("advanced/files/tailwind/input.css.tmpl", inputCssTemplate)
 }
 
 func OutputCssTemplate() []byte {
-	return outputCssTemplate
+	return template.Overlay("advanced/files/tailwind/output.css.tmpl", outputCssTemplate)
 }
 
 func HtmxTailwindConfigJsTemplate() []byte {
-	return htmxTailwindConfigJsTemplate
+	return template.Overlay("advanced/files/htmx/tailwind/tailwind.config.js.tmpl", htmxTailwindConfigJsTemplate)
 }
 
 func HtmxJSTemplate() []byte {
-	return htmxMinJsTemplate
+	return template.Overlay("advanced/files/htmx/htmx.min.js.tmpl", htmxMinJsTemplate)
 }
 
 func EfsTemplate() []byte {
-	return efsTemplate
+	return template.Overlay("advanced/files/htmx/efs.go.tmpl", efsTemplate)
 }
 
 func HelloGoTemplate() []byte {
-	return helloGoTemplate
+	return template.Overlay("advanced/files/htmx/hello.go.tmpl", helloGoTemplate)
 }
 
 func HelloFiberGoTemplate() []byte {
-	return helloFiberGoTemplate
+	return template.Overlay("advanced/files/htmx/hello_fiber.go.tmpl", helloFiberGoTemplate)
 }
 
 func FiberHtmxTemplRoutesTemplate() []byte {
-	return fiberHtmxTemplRoutes
+	return template.Overlay("advanced/files/htmx/routes/fiber.tmpl", fiberHtmxTemplRoutes)
 }
 
 func FiberHtmxTemplImportsTemplate() []byte {
-	return fiberHtmxTemplImports
+	return template.Overlay("advanced/files/htmx/imports/fiber.tmpl", fiberHtmxTemplImports)
 }
 
 func FiberWebsocketTemplImportsTemplate() []byte {
-	return fiberWebsocketTemplImports
+	return template.Overlay("advanced/files/websocket/imports/fiber.tmpl", fiberWebsocketTemplImports)
 }
 
 func GinHtmxTemplImportsTemplate() []byte {
-	return ginHtmxTemplImports
+	return template.Overlay("advanced/files/htmx/imports/gin.tmpl", ginHtmxTemplImports)
 }
@@ -0,0 +1,39 @@
+package dbdriver
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/migrations/migrations.go.tmpl
+var migrationsTemplate []byte
+
+//go:embed files/migrations/init.up.sql.tmpl
+var initUpMigrationTemplate []byte
+
+//go:embed files/migrations/init.down.sql.tmpl
+var initDownMigrationTemplate []byte
+
+//go:embed files/migrations/main.go.tmpl
+var migrateMainTemplate []byte
+
+// MigrationsTemplate returns the package embedding the migrations
+func MigrationsTemplate() []byte {
+	return template.Overlay("dbdriver/files/migrations/migrations.go.tmpl", migrationsTemplate)
+}
+
+// InitUpMigrationTemplate and InitDownMigrationTemplate
+// return the first migration of a project
+func InitUpMigrationTemplate() []byte {
+	return template.Overlay("dbdriver/files/migrations/init.up.sql.tmpl", initUpMigrationTemplate)
+}
+
+func InitDownMigrationTemplate() []byte {
+	return template.Overlay("dbdriver/files/migrations/init.down.sql.tmpl", initDownMigrationTemplate)
+}
+
+// MigrateMainTemplate returns the command applying and creating migrations
+func MigrateMainTemplate() []byte {
+	return template.Overlay("dbdriver/files/migrations/main.go.tmpl", migrateMainTemplate)
+}
@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type MongoTemplate struct{}
@@ -12,17 +14,38 @@ var mongoServiceTemplate []byte
 //go:embed files/env/mongo.tmpl
 var mongoEnvTemplate []byte
 
+//go:embed files/config/mongo.tmpl
+var mongoConfigTemplate []byte
+
 //go:embed files/tests/mongo.tmpl
 var mongoTestcontainersTemplate []byte
 
+//go:embed files/resource/mongo.tmpl
+var mongoRepositoryTemplate []byte
+
+//go:embed files/resource/mongo.blocks.tmpl
+var mongoRepositoryBlocksTemplate []byte
+
 func (m MongoTemplate) Service() []byte {
-	return mongoServiceTemplate
+	return template.Overlay("dbdriver/files/service/mongo.tmpl", mongoServiceTemplate)
 }
 
 func (m MongoTemplate) Env() []byte {
-	return mongoEnvTemplate
+	return template.Overlay("dbdriver/files/env/mongo.tmpl", mongoEnvTemplate)
+}
+
+func (m MongoTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/mongo.tmpl", mongoConfigTemplate)
 }
 
 func (m MongoTemplate) Tests() []byte {
-	return mongoTestcontainersTemplate
+	return template.Overlay("dbdriver/files/tests/mongo.tmpl", mongoTestcontainersTemplate)
+}
+
+func (m MongoTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/mongo.tmpl", mongoRepositoryTemplate)
+}
+
+func (m MongoTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/mongo.blocks.tmpl", mongoRepositoryBlocksTemplate)
 }
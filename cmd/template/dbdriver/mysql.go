@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type MysqlTemplate struct{}
@@ -12,17 +14,73 @@ var mysqlServiceTemplate []byte
 //go:embed files/env/mysql.tmpl
 var mysqlEnvTemplate []byte
 
+//go:embed files/config/mysql.tmpl
+var mysqlConfigTemplate []byte
+
 //go:embed files/tests/mysql.tmpl
 var mysqlTestcontainersTemplate []byte
 
+//go:embed files/migrate/mysql.tmpl
+var mysqlMigrateTemplate []byte
+
+//go:embed files/sqlc/mysql.yaml.tmpl
+var mysqlSqlcTemplate []byte
+
+//go:embed files/queries/mysql.sql.tmpl
+var mysqlQueriesTemplate []byte
+
+//go:embed files/queries/mysql.models.tmpl
+var mysqlQueriesModelsTemplate []byte
+
+//go:embed files/queries/mysql.go.tmpl
+var mysqlQueriesCodeTemplate []byte
+
+//go:embed files/resource/mysql.tmpl
+var mysqlRepositoryTemplate []byte
+
+//go:embed files/resource/mysql.blocks.tmpl
+var mysqlRepositoryBlocksTemplate []byte
+
 func (m MysqlTemplate) Service() []byte {
-	return mysqlServiceTemplate
+	return template.Overlay("dbdriver/files/service/mysql.tmpl", mysqlServiceTemplate)
 }
 
 func (m MysqlTemplate) Env() []byte {
-	return mysqlEnvTemplate
+	return template.Overlay("dbdriver/files/env/mysql.tmpl", mysqlEnvTemplate)
+}
+
+func (m MysqlTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/mysql.tmpl", mysqlConfigTemplate)
 }
 
 func (m MysqlTemplate) Tests() []byte {
-	return mysqlTestcontainersTemplate
+	return template.Overlay("dbdriver/files/tests/mysql.tmpl", mysqlTestcontainersTemplate)
+}
+
+func (m MysqlTemplate) Migrate() []byte {
+	return template.Overlay("dbdriver/files/migrate/mysql.tmpl", mysqlMigrateTemplate)
+}
+
+func (m MysqlTemplate) Sqlc() []byte {
+	return template.Overlay("dbdriver/files/sqlc/mysql.yaml.tmpl", mysqlSqlcTemplate)
+}
+
+func (m MysqlTemplate) Queries() []byte {
+	return template.Overlay("dbdriver/files/queries/mysql.sql.tmpl", mysqlQueriesTemplate)
+}
+
+func (m MysqlTemplate) QueriesModels() []byte {
+	return template.Overlay("dbdriver/files/queries/mysql.models.tmpl", mysqlQueriesModelsTemplate)
+}
+
+func (m MysqlTemplate) QueriesCode() []byte {
+	return template.Overlay("dbdriver/files/queries/mysql.go.tmpl", mysqlQueriesCodeTemplate)
+}
+
+func (m MysqlTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/mysql.tmpl", mysqlRepositoryTemplate)
+}
+
+func (m MysqlTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/mysql.blocks.tmpl", mysqlRepositoryBlocksTemplate)
 }
@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type PostgresTemplate struct{}
@@ -12,17 +14,73 @@ var postgresServiceTemplate []byte
 //go:embed files/env/postgres.tmpl
 var postgresEnvTemplate []byte
 
+//go:embed files/config/postgres.tmpl
+var postgresConfigTemplate []byte
+
 //go:embed files/tests/postgres.tmpl
 var postgresTestcontainersTemplate []byte
 
+//go:embed files/migrate/postgres.tmpl
+var postgresMigrateTemplate []byte
+
+//go:embed files/sqlc/postgres.yaml.tmpl
+var postgresSqlcTemplate []byte
+
+//go:embed files/queries/postgres.sql.tmpl
+var postgresQueriesTemplate []byte
+
+//go:embed files/queries/postgres.models.tmpl
+var postgresQueriesModelsTemplate []byte
+
+//go:embed files/queries/postgres.go.tmpl
+var postgresQueriesCodeTemplate []byte
+
+//go:embed files/resource/postgres.tmpl
+var postgresRepositoryTemplate []byte
+
+//go:embed files/resource/postgres.blocks.tmpl
+var postgresRepositoryBlocksTemplate []byte
+
 func (m PostgresTemplate) Service() []byte {
-	return postgresServiceTemplate
+	return template.Overlay("dbdriver/files/service/postgres.tmpl", postgresServiceTemplate)
 }
 
 func (m PostgresTemplate) Env() []byte {
-	return postgresEnvTemplate
+	return template.Overlay("dbdriver/files/env/postgres.tmpl", postgresEnvTemplate)
+}
+
+func (m PostgresTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/postgres.tmpl", postgresConfigTemplate)
 }
 
 func (m PostgresTemplate) Tests() []byte {
-	return postgresTestcontainersTemplate
+	return template.Overlay("dbdriver/files/tests/postgres.tmpl", postgresTestcontainersTemplate)
+}
+
+func (m PostgresTemplate) Migrate() []byte {
+	return template.Overlay("dbdriver/files/migrate/postgres.tmpl", postgresMigrateTemplate)
+}
+
+func (m PostgresTemplate) Sqlc() []byte {
+	return template.Overlay("dbdriver/files/sqlc/postgres.yaml.tmpl", postgresSqlcTemplate)
+}
+
+func (m PostgresTemplate) Queries() []byte {
+	return template.Overlay("dbdriver/files/queries/postgres.sql.tmpl", postgresQueriesTemplate)
+}
+
+func (m PostgresTemplate) QueriesModels() []byte {
+	return template.Overlay("dbdriver/files/queries/postgres.models.tmpl", postgresQueriesModelsTemplate)
+}
+
+func (m PostgresTemplate) QueriesCode() []byte {
+	return template.Overlay("dbdriver/files/queries/postgres.go.tmpl", postgresQueriesCodeTemplate)
+}
+
+func (m PostgresTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/postgres.tmpl", postgresRepositoryTemplate)
+}
+
+func (m PostgresTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/postgres.blocks.tmpl", postgresRepositoryBlocksTemplate)
 }
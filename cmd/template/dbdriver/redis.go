@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type RedisTemplate struct{}
@@ -12,17 +14,38 @@ var redisServiceTemplate []byte
 //go:embed files/env/redis.tmpl
 var redisEnvTemplate []byte
 
+//go:embed files/config/redis.tmpl
+var redisConfigTemplate []byte
+
 //go:embed files/tests/redis.tmpl
 var redisTestcontainersTemplate []byte
 
+//go:embed files/resource/redis.tmpl
+var redisRepositoryTemplate []byte
+
+//go:embed files/resource/redis.blocks.tmpl
+var redisRepositoryBlocksTemplate []byte
+
 func (r RedisTemplate) Service() []byte {
-	return redisServiceTemplate
+	return template.Overlay("dbdriver/files/service/redis.tmpl", redisServiceTemplate)
 }
 
 func (r RedisTemplate) Env() []byte {
-	return redisEnvTemplate
+	return template.Overlay("dbdriver/files/env/redis.tmpl", redisEnvTemplate)
+}
+
+func (r RedisTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/redis.tmpl", redisConfigTemplate)
 }
 
 func (r RedisTemplate) Tests() []byte {
-	return redisTestcontainersTemplate
+	return template.Overlay("dbdriver/files/tests/redis.tmpl", redisTestcontainersTemplate)
+}
+
+func (r RedisTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/redis.tmpl", redisRepositoryTemplate)
+}
+
+func (r RedisTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/redis.blocks.tmpl", redisRepositoryBlocksTemplate)
 }
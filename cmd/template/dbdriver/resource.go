@@ -0,0 +1,41 @@
+package dbdriver
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/resource/blocks.tmpl
+var repositoryBlocksTemplate []byte
+
+//go:embed files/resource/memory.tmpl
+var memoryRepositoryTemplate []byte
+
+//go:embed files/resource/up.sql.tmpl
+var resourceUpMigrationTemplate []byte
+
+//go:embed files/resource/down.sql.tmpl
+var resourceDownMigrationTemplate []byte
+
+// RepositoryBlocksTemplate returns the blocks of the repositories of
+// the resources, among them the model of a resource and its ids
+func RepositoryBlocksTemplate() []byte {
+	return template.Overlay("dbdriver/files/resource/blocks.tmpl", repositoryBlocksTemplate)
+}
+
+// MemoryRepositoryTemplate returns the repository of a
+// resource for projects without a database
+func MemoryRepositoryTemplate() []byte {
+	return template.Overlay("dbdriver/files/resource/memory.tmpl", memoryRepositoryTemplate)
+}
+
+// ResourceUpMigrationTemplate and ResourceDownMigrationTemplate
+// return the migration creating the table of a resource
+func ResourceUpMigrationTemplate() []byte {
+	return template.Overlay("dbdriver/files/resource/up.sql.tmpl", resourceUpMigrationTemplate)
+}
+
+func ResourceDownMigrationTemplate() []byte {
+	return template.Overlay("dbdriver/files/resource/down.sql.tmpl", resourceDownMigrationTemplate)
+}
@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type ScyllaTemplate struct{}
@@ -12,17 +14,38 @@ var scyllaServiceTemplate []byte
 //go:embed files/env/scylla.tmpl
 var scyllaEnvTemplate []byte
 
+//go:embed files/config/scylla.tmpl
+var scyllaConfigTemplate []byte
+
 //go:embed files/tests/scylla.tmpl
 var scyllaTestcontainersTemplate []byte
 
+//go:embed files/resource/scylla.tmpl
+var scyllaRepositoryTemplate []byte
+
+//go:embed files/resource/scylla.blocks.tmpl
+var scyllaRepositoryBlocksTemplate []byte
+
 func (r ScyllaTemplate) Service() []byte {
-	return scyllaServiceTemplate
+	return template.Overlay("dbdriver/files/service/scylla.tmpl", scyllaServiceTemplate)
 }
 
 func (r ScyllaTemplate) Env() []byte {
-	return scyllaEnvTemplate
+	return template.Overlay("dbdriver/files/env/scylla.tmpl", scyllaEnvTemplate)
+}
+
+func (r ScyllaTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/scylla.tmpl", scyllaConfigTemplate)
 }
 
 func (r ScyllaTemplate) Tests() []byte {
-	return scyllaTestcontainersTemplate
+	return template.Overlay("dbdriver/files/tests/scylla.tmpl", scyllaTestcontainersTemplate)
+}
+
+func (r ScyllaTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/scylla.tmpl", scyllaRepositoryTemplate)
+}
+
+func (r ScyllaTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/scylla.blocks.tmpl", scyllaRepositoryBlocksTemplate)
 }
@@ -0,0 +1,24 @@
+package dbdriver
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/sqlc/sqlc.yaml.tmpl
+var sqlcTemplate []byte
+
+//go:embed files/sqlc/db.go.tmpl
+var queriesDBTemplate []byte
+
+// SqlcTemplate returns the beginning of sqlc.yaml, followed
+// by the entry of every store with typed queries
+func SqlcTemplate() []byte {
+	return template.Overlay("dbdriver/files/sqlc/sqlc.yaml.tmpl", sqlcTemplate)
+}
+
+// QueriesDBTemplate returns the database handle of the queries generated by sqlc
+func QueriesDBTemplate() []byte {
+	return template.Overlay("dbdriver/files/sqlc/db.go.tmpl", queriesDBTemplate)
+}
@@ -2,6 +2,8 @@ package dbdriver
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type SqliteTemplate struct{}
@@ -12,14 +14,70 @@ var sqliteServiceTemplate []byte
 //go:embed files/env/sqlite.tmpl
 var sqliteEnvTemplate []byte
 
+//go:embed files/config/sqlite.tmpl
+var sqliteConfigTemplate []byte
+
+//go:embed files/migrate/sqlite.tmpl
+var sqliteMigrateTemplate []byte
+
+//go:embed files/sqlc/sqlite.yaml.tmpl
+var sqliteSqlcTemplate []byte
+
+//go:embed files/queries/sqlite.sql.tmpl
+var sqliteQueriesTemplate []byte
+
+//go:embed files/queries/sqlite.models.tmpl
+var sqliteQueriesModelsTemplate []byte
+
+//go:embed files/queries/sqlite.go.tmpl
+var sqliteQueriesCodeTemplate []byte
+
+//go:embed files/resource/sqlite.tmpl
+var sqliteRepositoryTemplate []byte
+
+//go:embed files/resource/sqlite.blocks.tmpl
+var sqliteRepositoryBlocksTemplate []byte
+
 func (m SqliteTemplate) Service() []byte {
-	return sqliteServiceTemplate
+	return template.Overlay("dbdriver/files/service/sqlite.tmpl", sqliteServiceTemplate)
 }
 
 func (m SqliteTemplate) Env() []byte {
-	return sqliteEnvTemplate
+	return template.Overlay("dbdriver/files/env/sqlite.tmpl", sqliteEnvTemplate)
+}
+
+func (m SqliteTemplate) Config() []byte {
+	return template.Overlay("dbdriver/files/config/sqlite.tmpl", sqliteConfigTemplate)
 }
 
 func (m SqliteTemplate) Tests() []byte {
 	return []byte{}
 }
+
+func (m SqliteTemplate) Migrate() []byte {
+	return template.Overlay("dbdriver/files/migrate/sqlite.tmpl", sqliteMigrateTemplate)
+}
+
+func (m SqliteTemplate) Sqlc() []byte {
+	return template.Overlay("dbdriver/files/sqlc/sqlite.yaml.tmpl", sqliteSqlcTemplate)
+}
+
+func (m SqliteTemplate) Queries() []byte {
+	return template.Overlay("dbdriver/files/queries/sqlite.sql.tmpl", sqliteQueriesTemplate)
+}
+
+func (m SqliteTemplate) QueriesModels() []byte {
+	return template.Overlay("dbdriver/files/queries/sqlite.models.tmpl", sqliteQueriesModelsTemplate)
+}
+
+func (m SqliteTemplate) QueriesCode() []byte {
+	return template.Overlay("dbdriver/files/queries/sqlite.go.tmpl", sqliteQueriesCodeTemplate)
+}
+
+func (m SqliteTemplate) Repository() []byte {
+	return template.Overlay("dbdriver/files/resource/sqlite.tmpl", sqliteRepositoryTemplate)
+}
+
+func (m SqliteTemplate) RepositoryBlocks() []byte {
+	return template.Overlay("dbdriver/files/resource/sqlite.blocks.tmpl", sqliteRepositoryBlocksTemplate)
+}
@@ -0,0 +1,16 @@
+package dbdriver
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/stores/database.tmpl
+var storesTemplate []byte
+
+// StoresTemplate returns the database package of projects with
+// several drivers, which gives access to the package of every store
+func StoresTemplate() []byte {
+	return template.Overlay("dbdriver/files/stores/database.tmpl", storesTemplate)
+}
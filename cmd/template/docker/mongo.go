@@ -2,6 +2,8 @@ package docker
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type MongoDockerTemplate struct{}
@@ -10,5 +12,5 @@ type MongoDockerTemplate struct{}
 var mongoDockerTemplate []byte
 
 func (m MongoDockerTemplate) Docker() []byte {
-	return mongoDockerTemplate
+	return template.Overlay("docker/files/docker-compose/mongo.tmpl", mongoDockerTemplate)
 }
\ No newline at end of file
@@ -2,6 +2,8 @@ package docker
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type MysqlDockerTemplate struct{}
@@ -10,5 +12,5 @@ type MysqlDockerTemplate struct{}
 var mysqlDockerTemplate []byte
 
 func (m MysqlDockerTemplate) Docker() []byte {
-	return mysqlDockerTemplate
+	return template.Overlay("docker/files/docker-compose/mysql.tmpl", mysqlDockerTemplate)
 }
\ No newline at end of file
@@ -2,6 +2,8 @@ package docker
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type PostgresDockerTemplate struct{}
@@ -10,5 +12,5 @@ type PostgresDockerTemplate struct{}
 var postgresDockerTemplate []byte
 
 func (m PostgresDockerTemplate) Docker() []byte {
-	return postgresDockerTemplate
+	return template.Overlay("docker/files/docker-compose/postgres.tmpl", postgresDockerTemplate)
 }
\ No newline at end of file
@@ -2,6 +2,8 @@ package docker
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type RedisDockerTemplate struct{}
@@ -10,5 +12,5 @@ type RedisDockerTemplate struct{}
 var redisDockerTemplate []byte
 
 func (r RedisDockerTemplate) Docker() []byte {
-	return redisDockerTemplate
+	return template.Overlay("docker/files/docker-compose/redis.tmpl", redisDockerTemplate)
 }
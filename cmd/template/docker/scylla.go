@@ -2,6 +2,8 @@ package docker
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 type ScyllaDockerTemplate struct{}
@@ -10,5 +12,5 @@ type ScyllaDockerTemplate struct{}
 var scyllaDockerTemplate []byte
 
 func (r ScyllaDockerTemplate) Docker() []byte {
-	return scyllaDockerTemplate
+	return template.Overlay("docker/files/docker-compose/scylla.tmpl", scyllaDockerTemplate)
 }
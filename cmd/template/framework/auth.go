@@ -0,0 +1,67 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/auth/handlers/http.tmpl
+var authHTTPHandlersTemplate []byte
+
+//go:embed files/auth/tests/cases.tmpl
+var authTestCasesTemplate []byte
+
+//go:embed files/auth/handlers/chi.go.tmpl
+var chiAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/chi.go.tmpl
+var chiAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/gin.go.tmpl
+var ginAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/gin.go.tmpl
+var ginAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/echo.go.tmpl
+var echoAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/echo.go.tmpl
+var echoAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/fiber.go.tmpl
+var fiberAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/fiber.go.tmpl
+var fiberAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/gorilla.go.tmpl
+var gorillaAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/gorilla.go.tmpl
+var gorillaAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/http_router.go.tmpl
+var httpRouterAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/http_router.go.tmpl
+var httpRouterAuthTestsTemplate []byte
+
+//go:embed files/auth/handlers/standard_library.go.tmpl
+var standardLibraryAuthHandlersTemplate []byte
+
+//go:embed files/auth/tests/standard_library.go.tmpl
+var standardLibraryAuthTestsTemplate []byte
+
+// AuthHTTPHandlersTemplate returns the blocks of the authentication
+// handlers shared by the frameworks serving net/http handlers
+func AuthHTTPHandlersTemplate() []byte {
+	return template.Overlay("framework/files/auth/handlers/http.tmpl", authHTTPHandlersTemplate)
+}
+
+// AuthTestCasesTemplate returns the blocks shared by the tests
+// of the authentication handlers
+func AuthTestCasesTemplate() []byte {
+	return template.Overlay("framework/files/auth/tests/cases.tmpl", authTestCasesTemplate)
+}
@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -17,19 +18,19 @@ var chiTestHandlerTemplate []byte
 type ChiTemplates struct{}
 
 func (c ChiTemplates) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 
 func (c ChiTemplates) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (c ChiTemplates) Routes() []byte {
-	return chiRoutesTemplate
+	return template.Overlay("framework/files/routes/chi.go.tmpl", chiRoutesTemplate)
 }
 
 func (c ChiTemplates) TestHandler() []byte {
-	return chiTestHandlerTemplate
+	return template.Overlay("framework/files/tests/default-test.go.tmpl", chiTestHandlerTemplate)
 }
 
 func (c ChiTemplates) HtmxTemplImports() []byte {
@@ -43,3 +44,35 @@ func (c ChiTemplates) HtmxTemplRoutes() []byte {
 func (c ChiTemplates) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (c ChiTemplates) LoggerMiddleware() []byte {
+	return httpLoggerMiddleware()
+}
+
+func (c ChiTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/chi.go.tmpl", chiOpenAPIHandlersTemplate)
+}
+
+func (c ChiTemplates) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (c ChiTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/chi.go.tmpl", chiResourceHandlersTemplate)
+}
+
+func (c ChiTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/chi.go.tmpl", chiResourceTestsTemplate)
+}
+
+func (c ChiTemplates) ResourceRouter() string {
+	return "r"
+}
+
+func (c ChiTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/chi.go.tmpl", chiAuthHandlersTemplate)
+}
+
+func (c ChiTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/chi.go.tmpl", chiAuthTestsTemplate)
+}
@@ -0,0 +1,23 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/config/config.go.tmpl
+var configTemplate []byte
+
+//go:embed files/config/config_test.go.tmpl
+var configTestTemplate []byte
+
+// ConfigTemplate returns the config package loading the settings of
+// the application, followed by the settings of every store
+func ConfigTemplate() []byte {
+	return template.Overlay("framework/files/config/config.go.tmpl", configTemplate)
+}
+
+func ConfigTestTemplate() []byte {
+	return template.Overlay("framework/files/config/config_test.go.tmpl", configTestTemplate)
+}
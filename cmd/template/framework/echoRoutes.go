@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -17,18 +18,18 @@ var echoTestHandlerTemplate []byte
 type EchoTemplates struct{}
 
 func (e EchoTemplates) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 func (e EchoTemplates) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (e EchoTemplates) Routes() []byte {
-	return echoRoutesTemplate
+	return template.Overlay("framework/files/routes/echo.go.tmpl", echoRoutesTemplate)
 }
 
 func (e EchoTemplates) TestHandler() []byte {
-	return echoTestHandlerTemplate
+	return template.Overlay("framework/files/tests/echo-test.go.tmpl", echoTestHandlerTemplate)
 }
 
 func (e EchoTemplates) HtmxTemplImports() []byte {
@@ -42,3 +43,35 @@ func (e EchoTemplates) HtmxTemplRoutes() []byte {
 func (e EchoTemplates) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (e EchoTemplates) LoggerMiddleware() []byte {
+	return template.Overlay("framework/files/logger/echo.go.tmpl", echoLoggerMiddlewareTemplate)
+}
+
+func (e EchoTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/echo.go.tmpl", echoOpenAPIHandlersTemplate)
+}
+
+func (e EchoTemplates) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (e EchoTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/echo.go.tmpl", echoResourceHandlersTemplate)
+}
+
+func (e EchoTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/echo.go.tmpl", echoResourceTestsTemplate)
+}
+
+func (e EchoTemplates) ResourceRouter() string {
+	return "e"
+}
+
+func (e EchoTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/echo.go.tmpl", echoAuthHandlersTemplate)
+}
+
+func (e EchoTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/echo.go.tmpl", echoAuthTestsTemplate)
+}
@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -23,18 +24,18 @@ var fiberTestHandlerTemplate []byte
 type FiberTemplates struct{}
 
 func (f FiberTemplates) Main() []byte {
-	return fiberMainTemplate
+	return template.Overlay("framework/files/main/fiber_main.go.tmpl", fiberMainTemplate)
 }
 func (f FiberTemplates) Server() []byte {
-	return fiberServerTemplate
+	return template.Overlay("framework/files/server/fiber.go.tmpl", fiberServerTemplate)
 }
 
 func (f FiberTemplates) Routes() []byte {
-	return fiberRoutesTemplate
+	return template.Overlay("framework/files/routes/fiber.go.tmpl", fiberRoutesTemplate)
 }
 
 func (f FiberTemplates) TestHandler() []byte {
-	return fiberTestHandlerTemplate
+	return template.Overlay("framework/files/tests/fiber-test.go.tmpl", fiberTestHandlerTemplate)
 }
 
 func (f FiberTemplates) HtmxTemplImports() []byte {
@@ -48,3 +49,35 @@ func (f FiberTemplates) HtmxTemplRoutes() []byte {
 func (f FiberTemplates) WebsocketImports() []byte {
 	return advanced.FiberWebsocketTemplImportsTemplate()
 }
+
+func (f FiberTemplates) LoggerMiddleware() []byte {
+	return template.Overlay("framework/files/logger/fiber.go.tmpl", fiberLoggerMiddlewareTemplate)
+}
+
+func (f FiberTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/fiber.go.tmpl", fiberOpenAPIHandlersTemplate)
+}
+
+func (f FiberTemplates) OpenAPITests() []byte {
+	return template.Overlay("framework/files/openapi/tests/fiber-test.go.tmpl", fiberOpenAPITestsTemplate)
+}
+
+func (f FiberTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/fiber.go.tmpl", fiberResourceHandlersTemplate)
+}
+
+func (f FiberTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/fiber.go.tmpl", fiberResourceTestsTemplate)
+}
+
+func (f FiberTemplates) ResourceRouter() string {
+	return "s.App"
+}
+
+func (f FiberTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/fiber.go.tmpl", fiberAuthHandlersTemplate)
+}
+
+func (f FiberTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/fiber.go.tmpl", fiberAuthTestsTemplate)
+}
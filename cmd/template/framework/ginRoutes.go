@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -17,19 +18,19 @@ var ginTestHandlerTemplate []byte
 type GinTemplates struct{}
 
 func (g GinTemplates) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 
 func (g GinTemplates) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (g GinTemplates) Routes() []byte {
-	return ginRoutesTemplate
+	return template.Overlay("framework/files/routes/gin.go.tmpl", ginRoutesTemplate)
 }
 
 func (g GinTemplates) TestHandler() []byte {
-	return ginTestHandlerTemplate
+	return template.Overlay("framework/files/tests/gin-test.go.tmpl", ginTestHandlerTemplate)
 }
 
 func (g GinTemplates) HtmxTemplImports() []byte {
@@ -43,3 +44,35 @@ func (g GinTemplates) HtmxTemplRoutes() []byte {
 func (g GinTemplates) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (g GinTemplates) LoggerMiddleware() []byte {
+	return template.Overlay("framework/files/logger/gin.go.tmpl", ginLoggerMiddlewareTemplate)
+}
+
+func (g GinTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/gin.go.tmpl", ginOpenAPIHandlersTemplate)
+}
+
+func (g GinTemplates) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (g GinTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/gin.go.tmpl", ginResourceHandlersTemplate)
+}
+
+func (g GinTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/gin.go.tmpl", ginResourceTestsTemplate)
+}
+
+func (g GinTemplates) ResourceRouter() string {
+	return "r"
+}
+
+func (g GinTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/gin.go.tmpl", ginAuthHandlersTemplate)
+}
+
+func (g GinTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/gin.go.tmpl", ginAuthTestsTemplate)
+}
@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -17,19 +18,19 @@ var gorillaTestHandlerTemplate []byte
 type GorillaTemplates struct{}
 
 func (g GorillaTemplates) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 
 func (g GorillaTemplates) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (g GorillaTemplates) Routes() []byte {
-	return gorillaRoutesTemplate
+	return template.Overlay("framework/files/routes/gorilla.go.tmpl", gorillaRoutesTemplate)
 }
 
 func (g GorillaTemplates) TestHandler() []byte {
-	return gorillaTestHandlerTemplate
+	return template.Overlay("framework/files/tests/default-test.go.tmpl", gorillaTestHandlerTemplate)
 }
 
 func (g GorillaTemplates) HtmxTemplImports() []byte {
@@ -43,3 +44,35 @@ func (g GorillaTemplates) HtmxTemplRoutes() []byte {
 func (g GorillaTemplates) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (g GorillaTemplates) LoggerMiddleware() []byte {
+	return httpLoggerMiddleware()
+}
+
+func (g GorillaTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/gorilla.go.tmpl", gorillaOpenAPIHandlersTemplate)
+}
+
+func (g GorillaTemplates) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (g GorillaTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/gorilla.go.tmpl", gorillaResourceHandlersTemplate)
+}
+
+func (g GorillaTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/gorilla.go.tmpl", gorillaResourceTestsTemplate)
+}
+
+func (g GorillaTemplates) ResourceRouter() string {
+	return "r"
+}
+
+func (g GorillaTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/gorilla.go.tmpl", gorillaAuthHandlersTemplate)
+}
+
+func (g GorillaTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/gorilla.go.tmpl", gorillaAuthTestsTemplate)
+}
@@ -0,0 +1,23 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/health/health.go.tmpl
+var healthTemplate []byte
+
+//go:embed files/health/health_test.go.tmpl
+var healthTestTemplate []byte
+
+// HealthTemplate returns the health package answering the liveness
+// and readiness probes, shared by every framework
+func HealthTemplate() []byte {
+	return template.Overlay("framework/files/health/health.go.tmpl", healthTemplate)
+}
+
+func HealthTestTemplate() []byte {
+	return template.Overlay("framework/files/health/health_test.go.tmpl", healthTestTemplate)
+}
@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -20,19 +21,19 @@ var standardTestHandlerTemplate []byte
 type StandardLibTemplate struct{}
 
 func (s StandardLibTemplate) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 
 func (s StandardLibTemplate) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (s StandardLibTemplate) Routes() []byte {
-	return standardRoutesTemplate
+	return template.Overlay("framework/files/routes/standard_library.go.tmpl", standardRoutesTemplate)
 }
 
 func (s StandardLibTemplate) TestHandler() []byte {
-	return standardTestHandlerTemplate
+	return template.Overlay("framework/files/tests/default-test.go.tmpl", standardTestHandlerTemplate)
 }
 
 func (s StandardLibTemplate) HtmxTemplImports() []byte {
@@ -46,3 +47,35 @@ func (s StandardLibTemplate) HtmxTemplRoutes() []byte {
 func (s StandardLibTemplate) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (s StandardLibTemplate) LoggerMiddleware() []byte {
+	return httpLoggerMiddleware()
+}
+
+func (s StandardLibTemplate) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/standard_library.go.tmpl", standardLibraryOpenAPIHandlersTemplate)
+}
+
+func (s StandardLibTemplate) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (s StandardLibTemplate) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/standard_library.go.tmpl", standardLibraryResourceHandlersTemplate)
+}
+
+func (s StandardLibTemplate) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/standard_library.go.tmpl", standardLibraryResourceTestsTemplate)
+}
+
+func (s StandardLibTemplate) ResourceRouter() string {
+	return "mux"
+}
+
+func (s StandardLibTemplate) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/standard_library.go.tmpl", standardLibraryAuthHandlersTemplate)
+}
+
+func (s StandardLibTemplate) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/standard_library.go.tmpl", standardLibraryAuthTestsTemplate)
+}
@@ -0,0 +1,41 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/logger/logger.go.tmpl
+var loggerTemplate []byte
+
+//go:embed files/logger/logger_test.go.tmpl
+var loggerTestTemplate []byte
+
+//go:embed files/logger/http.go.tmpl
+var httpLoggerMiddlewareTemplate []byte
+
+//go:embed files/logger/gin.go.tmpl
+var ginLoggerMiddlewareTemplate []byte
+
+//go:embed files/logger/echo.go.tmpl
+var echoLoggerMiddlewareTemplate []byte
+
+//go:embed files/logger/fiber.go.tmpl
+var fiberLoggerMiddlewareTemplate []byte
+
+// LoggerTemplate returns the logger package setting up log/slog,
+// shared by every framework
+func LoggerTemplate() []byte {
+	return template.Overlay("framework/files/logger/logger.go.tmpl", loggerTemplate)
+}
+
+func LoggerTestTemplate() []byte {
+	return template.Overlay("framework/files/logger/logger_test.go.tmpl", loggerTestTemplate)
+}
+
+// httpLoggerMiddleware returns the request logging middleware of
+// the frameworks serving net/http handlers
+func httpLoggerMiddleware() []byte {
+	return template.Overlay("framework/files/logger/http.go.tmpl", httpLoggerMiddlewareTemplate)
+}
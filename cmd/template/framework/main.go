@@ -4,6 +4,8 @@ package framework
 
 import (
 	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
 )
 
 //go:embed files/main/main.go.tmpl
@@ -24,19 +26,19 @@ var gitIgnoreTemplate []byte
 // MakeTemplate returns a byte slice that represents
 // the default Makefile template.
 func MakeTemplate() []byte {
-	return makeTemplate
+	return template.Overlay("framework/files/makefile.tmpl", makeTemplate)
 }
 
 func GitIgnoreTemplate() []byte {
-	return gitIgnoreTemplate
+	return template.Overlay("framework/files/gitignore.tmpl", gitIgnoreTemplate)
 }
 
 func AirTomlTemplate() []byte {
-	return airTomlTemplate
+	return template.Overlay("framework/files/air.toml.tmpl", airTomlTemplate)
 }
 
 // ReadmeTemplate returns a byte slice that represents
 // the default README.md file template.
 func ReadmeTemplate() []byte {
-	return readmeTemplate
+	return template.Overlay("framework/files/README.md.tmpl", readmeTemplate)
 }
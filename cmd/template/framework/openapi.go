@@ -0,0 +1,47 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/openapi/types.go.tmpl
+var openAPITypesTemplate []byte
+
+//go:embed files/openapi/handlers/chi.go.tmpl
+var chiOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/gin.go.tmpl
+var ginOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/echo.go.tmpl
+var echoOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/fiber.go.tmpl
+var fiberOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/gorilla.go.tmpl
+var gorillaOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/http_router.go.tmpl
+var httpRouterOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/handlers/standard_library.go.tmpl
+var standardLibraryOpenAPIHandlersTemplate []byte
+
+//go:embed files/openapi/tests/default-test.go.tmpl
+var defaultOpenAPITestsTemplate []byte
+
+//go:embed files/openapi/tests/fiber-test.go.tmpl
+var fiberOpenAPITestsTemplate []byte
+
+// OpenAPITypesTemplate returns the template of the types
+// generated from the schemas of an OpenAPI document
+func OpenAPITypesTemplate() []byte {
+	return template.Overlay("framework/files/openapi/types.go.tmpl", openAPITypesTemplate)
+}
+
+func defaultOpenAPITests() []byte {
+	return template.Overlay("framework/files/openapi/tests/default-test.go.tmpl", defaultOpenAPITestsTemplate)
+}
@@ -0,0 +1,58 @@
+package framework
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/resource/tests/cases.tmpl
+var resourceTestCasesTemplate []byte
+
+//go:embed files/resource/handlers/chi.go.tmpl
+var chiResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/chi.go.tmpl
+var chiResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/gin.go.tmpl
+var ginResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/gin.go.tmpl
+var ginResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/echo.go.tmpl
+var echoResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/echo.go.tmpl
+var echoResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/fiber.go.tmpl
+var fiberResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/fiber.go.tmpl
+var fiberResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/gorilla.go.tmpl
+var gorillaResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/gorilla.go.tmpl
+var gorillaResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/http_router.go.tmpl
+var httpRouterResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/http_router.go.tmpl
+var httpRouterResourceTestsTemplate []byte
+
+//go:embed files/resource/handlers/standard_library.go.tmpl
+var standardLibraryResourceHandlersTemplate []byte
+
+//go:embed files/resource/tests/standard_library.go.tmpl
+var standardLibraryResourceTestsTemplate []byte
+
+// ResourceTestCasesTemplate returns the blocks shared by the handler
+// tests of a generated resource
+func ResourceTestCasesTemplate() []byte {
+	return template.Overlay("framework/files/resource/tests/cases.tmpl", resourceTestCasesTemplate)
+}
@@ -3,6 +3,7 @@ package framework
 import (
 	_ "embed"
 
+	"github.com/melkeydev/go-blueprint/cmd/template"
 	"github.com/melkeydev/go-blueprint/cmd/template/advanced"
 )
 
@@ -17,18 +18,18 @@ var httpRouterTestHandlerTemplate []byte
 type RouterTemplates struct{}
 
 func (r RouterTemplates) Main() []byte {
-	return mainTemplate
+	return template.Overlay("framework/files/main/main.go.tmpl", mainTemplate)
 }
 func (r RouterTemplates) Server() []byte {
-	return standardServerTemplate
+	return template.Overlay("framework/files/server/standard_library.go.tmpl", standardServerTemplate)
 }
 
 func (r RouterTemplates) Routes() []byte {
-	return httpRouterRoutesTemplate
+	return template.Overlay("framework/files/routes/http_router.go.tmpl", httpRouterRoutesTemplate)
 }
 
 func (r RouterTemplates) TestHandler() []byte {
-	return httpRouterTestHandlerTemplate
+	return template.Overlay("framework/files/tests/default-test.go.tmpl", httpRouterTestHandlerTemplate)
 }
 
 func (r RouterTemplates) HtmxTemplImports() []byte {
@@ -42,3 +43,39 @@ func (r RouterTemplates) HtmxTemplRoutes() []byte {
 func (r RouterTemplates) WebsocketImports() []byte {
 	return advanced.StdLibWebsocketTemplImportsTemplate()
 }
+
+func (r RouterTemplates) LoggerMiddleware() []byte {
+	return httpLoggerMiddleware()
+}
+
+func (r RouterTemplates) OpenAPIHandlers() []byte {
+	return template.Overlay("framework/files/openapi/handlers/http_router.go.tmpl", httpRouterOpenAPIHandlersTemplate)
+}
+
+func (r RouterTemplates) OpenAPITests() []byte {
+	return defaultOpenAPITests()
+}
+
+func (r RouterTemplates) ResourceHandlers() []byte {
+	return template.Overlay("framework/files/resource/handlers/http_router.go.tmpl", httpRouterResourceHandlersTemplate)
+}
+
+func (r RouterTemplates) ResourceTests() []byte {
+	return template.Overlay("framework/files/resource/tests/http_router.go.tmpl", httpRouterResourceTestsTemplate)
+}
+
+func (r RouterTemplates) ResourceRouter() string {
+	return "r"
+}
+
+func (r RouterTemplates) Title() string {
+	return "HttpRouter"
+}
+
+func (r RouterTemplates) AuthHandlers() []byte {
+	return template.Overlay("framework/files/auth/handlers/http_router.go.tmpl", httpRouterAuthHandlersTemplate)
+}
+
+func (r RouterTemplates) AuthTests() []byte {
+	return template.Overlay("framework/files/auth/tests/http_router.go.tmpl", httpRouterAuthTestsTemplate)
+}
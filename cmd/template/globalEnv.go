@@ -8,5 +8,5 @@ import (
 var globalEnvTemplate []byte
 
 func GlobalEnvTemplate() []byte {
-	return globalEnvTemplate
+	return Overlay("framework/files/globalenv.tmpl", globalEnvTemplate)
 }
@@ -0,0 +1,23 @@
+package orm
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+type BunTemplate struct{}
+
+//go:embed files/service/bun.tmpl
+var bunServiceTemplate []byte
+
+//go:embed files/tests/bun.tmpl
+var bunTestsTemplate []byte
+
+func (m BunTemplate) Service() []byte {
+	return template.Overlay("orm/files/service/bun.tmpl", bunServiceTemplate)
+}
+
+func (m BunTemplate) Tests() []byte {
+	return template.Overlay("orm/files/tests/bun.tmpl", bunTestsTemplate)
+}
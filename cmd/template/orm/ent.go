@@ -0,0 +1,44 @@
+package orm
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+type EntTemplate struct{}
+
+//go:embed files/service/ent.tmpl
+var entServiceTemplate []byte
+
+//go:embed files/tests/ent.tmpl
+var entTestsTemplate []byte
+
+//go:embed files/ent/schema.tmpl
+var entSchemaTemplate []byte
+
+//go:embed files/ent/generate.tmpl
+var entGenerateTemplate []byte
+
+//go:embed files/ent/tools.tmpl
+var entToolsTemplate []byte
+
+func (m EntTemplate) Service() []byte {
+	return template.Overlay("orm/files/service/ent.tmpl", entServiceTemplate)
+}
+
+func (m EntTemplate) Tests() []byte {
+	return template.Overlay("orm/files/tests/ent.tmpl", entTestsTemplate)
+}
+
+func (m EntTemplate) Schema() []byte {
+	return template.Overlay("orm/files/ent/schema.tmpl", entSchemaTemplate)
+}
+
+func (m EntTemplate) Generate() []byte {
+	return template.Overlay("orm/files/ent/generate.tmpl", entGenerateTemplate)
+}
+
+func (m EntTemplate) Tools() []byte {
+	return template.Overlay("orm/files/ent/tools.tmpl", entToolsTemplate)
+}
@@ -0,0 +1,23 @@
+package orm
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+type GormTemplate struct{}
+
+//go:embed files/service/gorm.tmpl
+var gormServiceTemplate []byte
+
+//go:embed files/tests/gorm.tmpl
+var gormTestsTemplate []byte
+
+func (m GormTemplate) Service() []byte {
+	return template.Overlay("orm/files/service/gorm.tmpl", gormServiceTemplate)
+}
+
+func (m GormTemplate) Tests() []byte {
+	return template.Overlay("orm/files/tests/gorm.tmpl", gormTestsTemplate)
+}
@@ -0,0 +1,19 @@
+// Package orm provides the templates of the ORMs the services of the
+// SQL databases can be generated with. They define the blocks the
+// service and test templates of the SQL drivers are completed with
+package orm
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+//go:embed files/blocks.tmpl
+var blocksTemplate []byte
+
+// BlocksTemplate returns the blocks of the SQL drivers templates,
+// empty for database/sql, which ORMs redefine
+func BlocksTemplate() []byte {
+	return template.Overlay("orm/files/blocks.tmpl", blocksTemplate)
+}
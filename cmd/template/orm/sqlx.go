@@ -0,0 +1,23 @@
+package orm
+
+import (
+	_ "embed"
+
+	"github.com/melkeydev/go-blueprint/cmd/template"
+)
+
+type SqlxTemplate struct{}
+
+//go:embed files/service/sqlx.tmpl
+var sqlxServiceTemplate []byte
+
+//go:embed files/tests/sqlx.tmpl
+var sqlxTestsTemplate []byte
+
+func (m SqlxTemplate) Service() []byte {
+	return template.Overlay("orm/files/service/sqlx.tmpl", sqlxServiceTemplate)
+}
+
+func (m SqlxTemplate) Tests() []byte {
+	return template.Overlay("orm/files/tests/sqlx.tmpl", sqlxTestsTemplate)
+}
@@ -0,0 +1,109 @@
+package template
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+// overlays holds the templates overriding the embedded ones, keyed
+// by their slash separated path relative to this directory
+var overlays = struct {
+	sync.Mutex
+	files map[string][]byte
+	used  map[string]bool
+}{}
+
+// DefaultOverlayDir returns the directory overlays are loaded from
+// when no other is given, $XDG_CONFIG_HOME/go-blueprint/templates
+// or ~/.config/go-blueprint/templates
+func DefaultOverlayDir() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configDir, "go-blueprint", "templates")
+}
+
+// LoadOverlays reads every file under dir as a template overriding
+// the embedded template with the same relative path, for example
+// framework/files/routes/chi.go.tmpl. Overlays loaded before are
+// replaced. The .tmpl files are parsed, so that a malformed overlay
+// is reported with its path before anything is generated
+func LoadOverlays(dir string) error {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(name, ".tmpl") {
+			if _, err := texttemplate.New(filepath.Base(name)).Parse(string(content)); err != nil {
+				return fmt.Errorf("invalid template %s: %w", name, err)
+			}
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	overlays.Lock()
+	defer overlays.Unlock()
+	overlays.files = files
+	overlays.used = make(map[string]bool)
+
+	return nil
+}
+
+// Overlay returns the overlay loaded for the template at name,
+// or the embedded template when there is none
+func Overlay(name string, embedded []byte) []byte {
+	overlays.Lock()
+	defer overlays.Unlock()
+
+	content, ok := overlays.files[name]
+	if !ok {
+		return embedded
+	}
+	overlays.used[name] = true
+
+	return content
+}
+
+// UnusedOverlays returns the path of the loaded overlays that no
+// template was looked up for, sorted. Their path does not match an
+// embedded template, or the generated project does not use it
+func UnusedOverlays() []string {
+	overlays.Lock()
+	defer overlays.Unlock()
+
+	var unused []string
+	for name := range overlays.files {
+		if !overlays.used[name] {
+			unused = append(unused, name)
+		}
+	}
+	slices.Sort(unused)
+
+	return unused
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"framework/files/routes/chi.go.tmpl": "package server // company routes",
+		"framework/files/routes/chi.tmpl":    "misspelled",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := LoadOverlays(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := LoadOverlays(t.TempDir()); err != nil {
+			t.Error(err)
+		}
+	})
+
+	embedded := []byte("package server")
+	if got := string(Overlay("framework/files/routes/chi.go.tmpl", embedded)); got != files["framework/files/routes/chi.go.tmpl"] {
+		t.Errorf("expected the overlay to replace the embedded template, got %q", got)
+	}
+	if got := string(Overlay("framework/files/routes/gin.go.tmpl", embedded)); got != string(embedded) {
+		t.Errorf("expected the embedded template without overlay, got %q", got)
+	}
+
+	if unused := UnusedOverlays(); !reflect.DeepEqual(unused, []string{"framework/files/routes/chi.tmpl"}) {
+		t.Errorf("expected the misspelled overlay to be unused, got %v", unused)
+	}
+}
+
+func TestLoadOverlaysMissingDir(t *testing.T) {
+	if err := LoadOverlays(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestLoadOverlaysMalformed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "framework", "files", "routes", "chi.go.tmpl")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("package server {{ .Broken"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := LoadOverlays(dir)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("expected an error naming %s, got %v", path, err)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/melkeydev/go-blueprint/cmd/program"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().String("path", ".", "Path of the project generated by go-blueprint")
+	addTemplateDirFlag(upgradeCmd)
+	upgradeCmd.Flags().Bool("dry-run", false, "Print the files the upgrade would change, without writing them")
+	upgradeCmd.Flags().Bool("force", false, "Upgrade even when the project has uncommitted changes")
+}
+
+// upgradeCmd defines the "upgrade" command for the CLI
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Bring an existing Blueprint project to the templates of this version",
+	Long: `Render a project previously generated with Go Blueprint again, with the options it was generated with, using the templates of this version.
+The result is merged with the project: changes made only by the templates or only locally are applied, and conflict markers are left where both changed the same lines.
+The files as originally generated are the base of the merge, they are found in git from the checksums recorded in .blueprint.yaml.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath, err := filepath.Abs(cmd.Flag("path").Value.String())
+		cobra.CheckErr(err)
+		cobra.CheckErr(loadTemplateOverlays(cmd))
+
+		flagDryRun, err := cmd.Flags().GetBool("dry-run")
+		cobra.CheckErr(err)
+		flagForce, err := cmd.Flags().GetBool("force")
+		cobra.CheckErr(err)
+
+		if !flagDryRun && !flagForce && program.HasUncommittedChanges(projectPath) {
+			cobra.CheckErr(fmt.Errorf("the project has uncommitted changes. Commit or stash them before upgrading, or use --force"))
+		}
+
+		project, err := program.DetectProject(projectPath)
+		cobra.CheckErr(err)
+		project.BlueprintVersion = getGoBlueprintVersion()
+
+		plan, err := project.PlanUpgrade(projectPath)
+		cobra.CheckErr(err)
+
+		fromVersion := plan.FromVersion
+		if fromVersion == "" {
+			fromVersion = "an unknown version"
+		}
+		fmt.Println(tipMsgStyle.Render(fmt.Sprintf("Upgrading %s from %s to %s", project.ProjectName, fromVersion, plan.ToVersion)))
+
+		if len(plan.Files) == 0 {
+			fmt.Println(endingMsgStyle.Render("\nThe project is up to date."))
+		}
+		for _, file := range plan.Files {
+			line := fmt.Sprintf("• %s (%s)", file.Path, file.Status)
+			if file.Conflicts > 0 {
+				line = fmt.Sprintf("• %s (%d conflict(s))", file.Path, file.Conflicts)
+			}
+			fmt.Println(endingMsgStyle.Render(line))
+		}
+		for _, file := range plan.Removed {
+			fmt.Println(tipMsgStyle.Render(fmt.Sprintf("• %s was removed from the project and is not recreated", file)))
+		}
+
+		if flagDryRun {
+			return
+		}
+
+		if err := plan.Apply(); err != nil {
+			log.Printf("Problem upgrading project.")
+			cobra.CheckErr(err)
+		}
+
+		if conflicts := plan.Conflicts(); conflicts > 0 {
+			fmt.Println(endingMsgStyle.Render(fmt.Sprintf("\n%d conflict(s) left between <<<<<<< and >>>>>>> markers.", conflicts)))
+			fmt.Println(endingMsgStyle.Render("Resolve them, then run `go mod tidy`"))
+		}
+		if len(plan.Files) > 0 {
+			fmt.Println(tipMsgStyle.Render("\nReview and commit the changes with git"))
+		}
+	},
+}
@@ -37,7 +37,7 @@ func NonInteractiveCommand(use string, flagSet *pflag.FlagSet) string {
 				if flag.Value.String() == "true" {
 					nonInteractiveCommand = fmt.Sprintf("%s --%s", nonInteractiveCommand, flag.Name)
 				}
-			} else {
+			} else if flag.Value.String() != "" {
 				nonInteractiveCommand = fmt.Sprintf("%s --%s %s", nonInteractiveCommand, flag.Name, flag.Value.String())
 			}
 		}
@@ -1,6 +1,10 @@
 package utils
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
 
 func TestValidateModuleName(t *testing.T) {
 	passTestCases := []string{
@@ -55,3 +59,18 @@ func TestGeRootDir(t *testing.T) {
 		}
 	}
 }
+
+func TestNonInteractiveCommand(t *testing.T) {
+	flagSet := pflag.NewFlagSet("create", pflag.ContinueOnError)
+	flagSet.String("name", "", "")
+	flagSet.String("output-archive", "", "")
+	flagSet.Bool("dry-run", false, "")
+	if err := flagSet.Parse([]string{"--name", "project", "--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "go-blueprint create --name project --dry-run"
+	if command := NonInteractiveCommand("create", flagSet); command != expected {
+		t.Errorf("expected:%s got:%s", expected, command)
+	}
+}
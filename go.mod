@@ -8,6 +8,7 @@ require (
 	github.com/charmbracelet/lipgloss v0.9.0
 	github.com/spf13/cobra v1.7.0
 	github.com/spf13/pflag v1.0.5
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (